@@ -0,0 +1,354 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package check
+
+import "context"
+
+// Must1 returns t if err is nil, otherwise it calls panic(Error{err}).
+//
+//	price := check.Must1(strconv.ParseFloat(unitPrice, 64)) *
+//		check.Must1(strconv.ParseFloat(qty, 64))
+func Must1[T any](t T, err error) T {
+	if err != nil {
+		raise(err)
+	}
+	return t
+}
+
+// Must2 returns t1, t2 if err is nil, otherwise it calls panic(Error{err}).
+func Must2[T1, T2 any](t1 T1, t2 T2, err error) (T1, T2) {
+	if err != nil {
+		raise(err)
+	}
+	return t1, t2
+}
+
+// Must3 returns t1, t2, t3 if err is nil, otherwise it calls panic(Error{err}).
+func Must3[T1, T2, T3 any](
+	t1 T1, t2 T2, t3 T3, err error,
+) (T1, T2, T3) {
+	if err != nil {
+		raise(err)
+	}
+	return t1, t2, t3
+}
+
+// Must4 returns t1, t2, t3, t4 if err is nil, otherwise it calls
+// panic(Error{err}).
+func Must4[T1, T2, T3, T4 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, err error,
+) (T1, T2, T3, T4) {
+	if err != nil {
+		raise(err)
+	}
+	return t1, t2, t3, t4
+}
+
+// Must5 returns t1, t2, t3, t4, t5 if err is nil, otherwise it calls
+// panic(Error{err}).
+func Must5[T1, T2, T3, T4, T5 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, err error,
+) (T1, T2, T3, T4, T5) {
+	if err != nil {
+		raise(err)
+	}
+	return t1, t2, t3, t4, t5
+}
+
+// Must6 returns t1, t2, t3, t4, t5, t6 if err is nil, otherwise it calls
+// panic(Error{err}).
+func Must6[T1, T2, T3, T4, T5, T6 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, t6 T6, err error,
+) (T1, T2, T3, T4, T5, T6) {
+	if err != nil {
+		raise(err)
+	}
+	return t1, t2, t3, t4, t5, t6
+}
+
+// Must1f returns a function that returns t if err is nil, otherwise it
+// calls panic(Error{fmt.Errorf(format+": %w", args..., err)}).
+//
+//	cfg := check.Must1f(os.Open(name))("opening config %s", name)
+func Must1f[T any](t T, err error) func(format string, args ...any) T {
+	return func(format string, args ...any) T {
+		if err != nil {
+			raiseNew(annotate(err, format, args))
+		}
+		return t
+	}
+}
+
+// Must2f returns a function that returns t1, t2 if err is nil, otherwise it
+// calls panic(Error{fmt.Errorf(format+": %w", args..., err)}).
+func Must2f[T1, T2 any](t1 T1, t2 T2, err error) func(format string, args ...any) (T1, T2) {
+	return func(format string, args ...any) (T1, T2) {
+		if err != nil {
+			raiseNew(annotate(err, format, args))
+		}
+		return t1, t2
+	}
+}
+
+// Must3f returns a function that returns t1, t2, t3 if err is nil, otherwise it
+// calls panic(Error{fmt.Errorf(format+": %w", args..., err)}).
+func Must3f[T1, T2, T3 any](
+	t1 T1, t2 T2, t3 T3, err error,
+) func(format string, args ...any) (T1, T2, T3) {
+	return func(format string, args ...any) (T1, T2, T3) {
+		if err != nil {
+			raiseNew(annotate(err, format, args))
+		}
+		return t1, t2, t3
+	}
+}
+
+// Must4f returns a function that returns t1, t2, t3, t4 if err is nil,
+// otherwise it calls panic(Error{fmt.Errorf(format+": %w", args..., err)}).
+func Must4f[T1, T2, T3, T4 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, err error,
+) func(format string, args ...any) (T1, T2, T3, T4) {
+	return func(format string, args ...any) (T1, T2, T3, T4) {
+		if err != nil {
+			raiseNew(annotate(err, format, args))
+		}
+		return t1, t2, t3, t4
+	}
+}
+
+// Must5f returns a function that returns t1, t2, t3, t4, t5 if err is nil,
+// otherwise it calls panic(Error{fmt.Errorf(format+": %w", args..., err)}).
+func Must5f[T1, T2, T3, T4, T5 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, err error,
+) func(format string, args ...any) (T1, T2, T3, T4, T5) {
+	return func(format string, args ...any) (T1, T2, T3, T4, T5) {
+		if err != nil {
+			raiseNew(annotate(err, format, args))
+		}
+		return t1, t2, t3, t4, t5
+	}
+}
+
+// Must6f returns a function that returns t1, t2, t3, t4, t5, t6 if err is nil,
+// otherwise it calls panic(Error{fmt.Errorf(format+": %w", args..., err)}).
+func Must6f[T1, T2, T3, T4, T5, T6 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, t6 T6, err error,
+) func(format string, args ...any) (T1, T2, T3, T4, T5, T6) {
+	return func(format string, args ...any) (T1, T2, T3, T4, T5, T6) {
+		if err != nil {
+			raiseNew(annotate(err, format, args))
+		}
+		return t1, t2, t3, t4, t5, t6
+	}
+}
+
+// Catch1 returns _, err if calling work panics with Error{err},
+// otherwise it returns t, nil.
+//
+//	func getTotalWeight(weight, qty string) (float64, error) {
+//		return Catch1(func() float64 {
+//			return Must1(strconv.ParseFloat(weight, 64)) *
+//				float64(Must1(strconv.Atoi(qty)))
+//		})
+//	}
+func Catch1[T any](
+	work func() T,
+	transforms ...func(e error) error,
+) (t T, e error) {
+	defer Handle(&e, transforms...)
+	t = work()
+	return
+}
+
+// Catch2 returns _, _, err if calling work panics with Error{err},
+// otherwise it returns t1, t2, nil. See Catch1 for a related example.
+func Catch2[T1, T2 any](
+	work func() (T1, T2),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, e error) {
+	defer Handle(&e, transforms...)
+	t1, t2 = work()
+	return
+}
+
+// Catch3 returns _, _, _, err if calling work panics with Error{err},
+// otherwise it returns t1, t2, t3, nil. See Catch1 for a related example.
+func Catch3[T1, T2, T3 any](
+	work func() (T1, T2, T3),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, t3 T3, e error) {
+	defer Handle(&e, transforms...)
+	t1, t2, t3 = work()
+	return
+}
+
+// Catch4 returns _, _, _, _, err if calling work panics with Error{err},
+// otherwise it returns t1, t2, t3, t4, nil. See Catch1 for a related example.
+func Catch4[T1, T2, T3, T4 any](
+	work func() (T1, T2, T3, T4),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, t3 T3, t4 T4, e error) {
+	defer Handle(&e, transforms...)
+	t1, t2, t3, t4 = work()
+	return
+}
+
+// Catch5 returns _, _, _, _, _, err if calling work panics with Error{err},
+// otherwise it returns t1, t2, t3, t4, t5, nil. See Catch1 for a related
+// example.
+func Catch5[T1, T2, T3, T4, T5 any](
+	work func() (T1, T2, T3, T4, T5),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, e error) {
+	defer Handle(&e, transforms...)
+	t1, t2, t3, t4, t5 = work()
+	return
+}
+
+// Catch6 returns _, _, _, _, _, _, err if calling work panics with Error{err},
+// otherwise it returns t1, t2, t3, t4, t5, t6, nil. See Catch1 for a related
+// example.
+func Catch6[T1, T2, T3, T4, T5, T6 any](
+	work func() (T1, T2, T3, T4, T5, T6),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, t6 T6, e error) {
+	defer Handle(&e, transforms...)
+	t1, t2, t3, t4, t5, t6 = work()
+	return
+}
+
+// CatchCtx1 behaves like Catch1 with the context handling of CatchCtx.
+func CatchCtx1[T any](
+	ctx context.Context,
+	work func() T,
+	transforms ...func(e error) error,
+) (t T, e error) {
+	defer Handle(&e, ctxTransforms(ctx, transforms)...)
+	MustCtx(ctx)
+	t = work()
+	return
+}
+
+// CatchCtx2 behaves like Catch2 with the context handling of CatchCtx.
+func CatchCtx2[T1, T2 any](
+	ctx context.Context,
+	work func() (T1, T2),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, e error) {
+	defer Handle(&e, ctxTransforms(ctx, transforms)...)
+	MustCtx(ctx)
+	t1, t2 = work()
+	return
+}
+
+// CatchCtx3 behaves like Catch3 with the context handling of CatchCtx.
+func CatchCtx3[T1, T2, T3 any](
+	ctx context.Context,
+	work func() (T1, T2, T3),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, t3 T3, e error) {
+	defer Handle(&e, ctxTransforms(ctx, transforms)...)
+	MustCtx(ctx)
+	t1, t2, t3 = work()
+	return
+}
+
+// CatchCtx4 behaves like Catch4 with the context handling of CatchCtx.
+func CatchCtx4[T1, T2, T3, T4 any](
+	ctx context.Context,
+	work func() (T1, T2, T3, T4),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, t3 T3, t4 T4, e error) {
+	defer Handle(&e, ctxTransforms(ctx, transforms)...)
+	MustCtx(ctx)
+	t1, t2, t3, t4 = work()
+	return
+}
+
+// CatchCtx5 behaves like Catch5 with the context handling of CatchCtx.
+func CatchCtx5[T1, T2, T3, T4, T5 any](
+	ctx context.Context,
+	work func() (T1, T2, T3, T4, T5),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, e error) {
+	defer Handle(&e, ctxTransforms(ctx, transforms)...)
+	MustCtx(ctx)
+	t1, t2, t3, t4, t5 = work()
+	return
+}
+
+// CatchCtx6 behaves like Catch6 with the context handling of CatchCtx.
+func CatchCtx6[T1, T2, T3, T4, T5, T6 any](
+	ctx context.Context,
+	work func() (T1, T2, T3, T4, T5, T6),
+	transforms ...func(e error) error,
+) (t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, t6 T6, e error) {
+	defer Handle(&e, ctxTransforms(ctx, transforms)...)
+	MustCtx(ctx)
+	t1, t2, t3, t4, t5, t6 = work()
+	return
+}
+
+// Collect1 returns a function that returns t after recording err in c
+// if it is not nil.
+//
+//	port := check.Collect1(strconv.Atoi(cfg.Port))(&c)
+func Collect1[T any](t T, err error) func(c *Collector) T {
+	return func(c *Collector) T {
+		c.Must(err)
+		return t
+	}
+}
+
+// Collect2 returns a function that returns t1, t2 after recording err in c
+// if it is not nil.
+func Collect2[T1, T2 any](t1 T1, t2 T2, err error) func(c *Collector) (T1, T2) {
+	return func(c *Collector) (T1, T2) {
+		c.Must(err)
+		return t1, t2
+	}
+}
+
+// Collect3 returns a function that returns t1, t2, t3 after recording err in c
+// if it is not nil.
+func Collect3[T1, T2, T3 any](
+	t1 T1, t2 T2, t3 T3, err error,
+) func(c *Collector) (T1, T2, T3) {
+	return func(c *Collector) (T1, T2, T3) {
+		c.Must(err)
+		return t1, t2, t3
+	}
+}
+
+// Collect4 returns a function that returns t1, t2, t3, t4 after recording err
+// in c if it is not nil.
+func Collect4[T1, T2, T3, T4 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, err error,
+) func(c *Collector) (T1, T2, T3, T4) {
+	return func(c *Collector) (T1, T2, T3, T4) {
+		c.Must(err)
+		return t1, t2, t3, t4
+	}
+}
+
+// Collect5 returns a function that returns t1, t2, t3, t4, t5 after recording
+// err in c if it is not nil.
+func Collect5[T1, T2, T3, T4, T5 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, err error,
+) func(c *Collector) (T1, T2, T3, T4, T5) {
+	return func(c *Collector) (T1, T2, T3, T4, T5) {
+		c.Must(err)
+		return t1, t2, t3, t4, t5
+	}
+}
+
+// Collect6 returns a function that returns t1, t2, t3, t4, t5, t6 after
+// recording err in c if it is not nil.
+func Collect6[T1, T2, T3, T4, T5, T6 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, t6 T6, err error,
+) func(c *Collector) (T1, T2, T3, T4, T5, T6) {
+	return func(c *Collector) (T1, T2, T3, T4, T5, T6) {
+		c.Must(err)
+		return t1, t2, t3, t4, t5, t6
+	}
+}
@@ -0,0 +1,379 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package check_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+
+func TestArity1(t *testing.T) {
+	t.Parallel()
+
+	want := []int{1}
+	succeed := func() int {
+		return check.Must1(1, nil)
+	}
+	fail := func() int {
+		return check.Must1(1, errOops)
+	}
+
+	v1, err := check.Catch1(succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1})
+	_, err = check.Catch1(fail)
+	assert.Equal(t, errOops, err)
+
+	v1, err = check.CatchCtx1(context.Background(), succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1})
+	_, err = check.CatchCtx1(context.Background(), fail)
+	assert.Equal(t, errOops, err)
+
+	v1, err = check.Catch1(func() int {
+		return check.Must1f(1, nil)("arity %d", 1)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1})
+	_, err = check.Catch1(func() int {
+		return check.Must1f(1, errOops)("arity %d", 1)
+	})
+	assert.EqualError(t, err, "arity 1: oops")
+
+	var c check.Collector
+	v1 = check.Collect1(1, errOops)(&c)
+	assert.Equal(t, want, []int{v1})
+	assert.Equal(t, errOops, c.Err())
+}
+
+func TestArity2(t *testing.T) {
+	t.Parallel()
+
+	want := []int{1, 2}
+	succeed := func() (int, int) {
+		return check.Must2(1, 2, nil)
+	}
+	fail := func() (int, int) {
+		return check.Must2(1, 2, errOops)
+	}
+
+	v1, v2, err := check.Catch2(succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2})
+	_, _, err = check.Catch2(fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, err = check.CatchCtx2(context.Background(), succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2})
+	_, _, err = check.CatchCtx2(context.Background(), fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, err = check.Catch2(func() (int, int) {
+		return check.Must2f(1, 2, nil)("arity %d", 2)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2})
+	_, _, err = check.Catch2(func() (int, int) {
+		return check.Must2f(1, 2, errOops)("arity %d", 2)
+	})
+	assert.EqualError(t, err, "arity 2: oops")
+
+	var c check.Collector
+	v1, v2 = check.Collect2(1, 2, errOops)(&c)
+	assert.Equal(t, want, []int{v1, v2})
+	assert.Equal(t, errOops, c.Err())
+}
+
+func TestArity3(t *testing.T) {
+	t.Parallel()
+
+	want := []int{1, 2, 3}
+	succeed := func() (int, int, int) {
+		return check.Must3(1, 2, 3, nil)
+	}
+	fail := func() (int, int, int) {
+		return check.Must3(1, 2, 3, errOops)
+	}
+
+	v1, v2, v3, err := check.Catch3(succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3})
+	_, _, _, err = check.Catch3(fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, v3, err = check.CatchCtx3(context.Background(), succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3})
+	_, _, _, err = check.CatchCtx3(context.Background(), fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, v3, err = check.Catch3(func() (int, int, int) {
+		return check.Must3f(1, 2, 3, nil)("arity %d", 3)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3})
+	_, _, _, err = check.Catch3(func() (int, int, int) {
+		return check.Must3f(1, 2, 3, errOops)("arity %d", 3)
+	})
+	assert.EqualError(t, err, "arity 3: oops")
+
+	var c check.Collector
+	v1, v2, v3 = check.Collect3(1, 2, 3, errOops)(&c)
+	assert.Equal(t, want, []int{v1, v2, v3})
+	assert.Equal(t, errOops, c.Err())
+}
+
+func TestArity4(t *testing.T) {
+	t.Parallel()
+
+	want := []int{1, 2, 3, 4}
+	succeed := func() (int, int, int, int) {
+		return check.Must4(1, 2, 3, 4, nil)
+	}
+	fail := func() (int, int, int, int) {
+		return check.Must4(1, 2, 3, 4, errOops)
+	}
+
+	v1, v2, v3, v4, err := check.Catch4(succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4})
+	_, _, _, _, err = check.Catch4(fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, v3, v4, err = check.CatchCtx4(context.Background(), succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4})
+	_, _, _, _, err = check.CatchCtx4(context.Background(), fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, v3, v4, err = check.Catch4(func() (int, int, int, int) {
+		return check.Must4f(1, 2, 3, 4, nil)("arity %d", 4)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4})
+	_, _, _, _, err = check.Catch4(func() (int, int, int, int) {
+		return check.Must4f(1, 2, 3, 4, errOops)("arity %d", 4)
+	})
+	assert.EqualError(t, err, "arity 4: oops")
+
+	var c check.Collector
+	v1, v2, v3, v4 = check.Collect4(1, 2, 3, 4, errOops)(&c)
+	assert.Equal(t, want, []int{v1, v2, v3, v4})
+	assert.Equal(t, errOops, c.Err())
+}
+
+func TestArity5(t *testing.T) {
+	t.Parallel()
+
+	want := []int{1, 2, 3, 4, 5}
+	succeed := func() (int, int, int, int, int) {
+		return check.Must5(1, 2, 3, 4, 5, nil)
+	}
+	fail := func() (int, int, int, int, int) {
+		return check.Must5(1, 2, 3, 4, 5, errOops)
+	}
+
+	v1, v2, v3, v4, v5, err := check.Catch5(succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4, v5})
+	_, _, _, _, _, err = check.Catch5(fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, v3, v4, v5, err = check.CatchCtx5(context.Background(), succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4, v5})
+	_, _, _, _, _, err = check.CatchCtx5(context.Background(), fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, v3, v4, v5, err = check.Catch5(func() (int, int, int, int, int) {
+		return check.Must5f(1, 2, 3, 4, 5, nil)("arity %d", 5)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4, v5})
+	_, _, _, _, _, err = check.Catch5(func() (int, int, int, int, int) {
+		return check.Must5f(1, 2, 3, 4, 5, errOops)("arity %d", 5)
+	})
+	assert.EqualError(t, err, "arity 5: oops")
+
+	var c check.Collector
+	v1, v2, v3, v4, v5 = check.Collect5(1, 2, 3, 4, 5, errOops)(&c)
+	assert.Equal(t, want, []int{v1, v2, v3, v4, v5})
+	assert.Equal(t, errOops, c.Err())
+}
+
+func TestArity6(t *testing.T) {
+	t.Parallel()
+
+	want := []int{1, 2, 3, 4, 5, 6}
+	succeed := func() (int, int, int, int, int, int) {
+		return check.Must6(1, 2, 3, 4, 5, 6, nil)
+	}
+	fail := func() (int, int, int, int, int, int) {
+		return check.Must6(1, 2, 3, 4, 5, 6, errOops)
+	}
+
+	v1, v2, v3, v4, v5, v6, err := check.Catch6(succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4, v5, v6})
+	_, _, _, _, _, _, err = check.Catch6(fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, v3, v4, v5, v6, err = check.CatchCtx6(context.Background(), succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4, v5, v6})
+	_, _, _, _, _, _, err = check.CatchCtx6(context.Background(), fail)
+	assert.Equal(t, errOops, err)
+
+	v1, v2, v3, v4, v5, v6, err = check.Catch6(func() (int, int, int, int, int, int) {
+		return check.Must6f(1, 2, 3, 4, 5, 6, nil)("arity %d", 6)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{v1, v2, v3, v4, v5, v6})
+	_, _, _, _, _, _, err = check.Catch6(func() (int, int, int, int, int, int) {
+		return check.Must6f(1, 2, 3, 4, 5, 6, errOops)("arity %d", 6)
+	})
+	assert.EqualError(t, err, "arity 6: oops")
+
+	var c check.Collector
+	v1, v2, v3, v4, v5, v6 = check.Collect6(1, 2, 3, 4, 5, 6, errOops)(&c)
+	assert.Equal(t, want, []int{v1, v2, v3, v4, v5, v6})
+	assert.Equal(t, errOops, c.Err())
+}
+
+func BenchmarkSuccessMust1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		check.Must1(1, nil)
+	}
+}
+
+func BenchmarkSuccessCatch1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = check.Catch1(func() int {
+			return check.Must1(1, nil)
+		})
+	}
+}
+
+func BenchmarkFailureCatch1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = check.Catch1(func() int {
+			return check.Must1(1, errOops)
+		})
+	}
+}
+
+func BenchmarkSuccessMust2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		check.Must2(1, 2, nil)
+	}
+}
+
+func BenchmarkSuccessCatch2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = check.Catch2(func() (int, int) {
+			return check.Must2(1, 2, nil)
+		})
+	}
+}
+
+func BenchmarkFailureCatch2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = check.Catch2(func() (int, int) {
+			return check.Must2(1, 2, errOops)
+		})
+	}
+}
+
+func BenchmarkSuccessMust3(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		check.Must3(1, 2, 3, nil)
+	}
+}
+
+func BenchmarkSuccessCatch3(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _, _ = check.Catch3(func() (int, int, int) {
+			return check.Must3(1, 2, 3, nil)
+		})
+	}
+}
+
+func BenchmarkFailureCatch3(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _, _ = check.Catch3(func() (int, int, int) {
+			return check.Must3(1, 2, 3, errOops)
+		})
+	}
+}
+
+func BenchmarkSuccessMust4(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		check.Must4(1, 2, 3, 4, nil)
+	}
+}
+
+func BenchmarkSuccessCatch4(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _, _, _ = check.Catch4(func() (int, int, int, int) {
+			return check.Must4(1, 2, 3, 4, nil)
+		})
+	}
+}
+
+func BenchmarkFailureCatch4(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _, _, _ = check.Catch4(func() (int, int, int, int) {
+			return check.Must4(1, 2, 3, 4, errOops)
+		})
+	}
+}
+
+func BenchmarkSuccessMust5(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		check.Must5(1, 2, 3, 4, 5, nil)
+	}
+}
+
+func BenchmarkSuccessCatch5(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _, _, _, _ = check.Catch5(func() (int, int, int, int, int) {
+			return check.Must5(1, 2, 3, 4, 5, nil)
+		})
+	}
+}
+
+func BenchmarkFailureCatch5(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _, _, _, _ = check.Catch5(func() (int, int, int, int, int) {
+			return check.Must5(1, 2, 3, 4, 5, errOops)
+		})
+	}
+}
+
+func BenchmarkSuccessMust6(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		check.Must6(1, 2, 3, 4, 5, 6, nil)
+	}
+}
+
+func BenchmarkSuccessCatch6(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _, _, _, _, _ = check.Catch6(func() (int, int, int, int, int, int) {
+			return check.Must6(1, 2, 3, 4, 5, 6, nil)
+		})
+	}
+}
+
+func BenchmarkFailureCatch6(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _, _, _, _, _ = check.Catch6(func() (int, int, int, int, int, int) {
+			return check.Must6(1, 2, 3, 4, 5, 6, errOops)
+		})
+	}
+}
@@ -22,6 +22,13 @@ func call(f func() (int, error)) int {
 	return i
 }
 
+// bare is the floor for the success path: a deferred recover with no
+// handling.
+func bare(f func() (int, error)) int {
+	defer func() { _ = recover() }()
+	return check.Must1(f())
+}
+
 func catch(f func() (int, error)) (i int) {
 	if check.Catch(func() {
 		i = check.Must1(f())
@@ -46,6 +53,39 @@ func handleTransform(f func() (int, error)) (i int) {
 	return check.Must1(f())
 }
 
+func handleTransforms(f func() (int, error)) (i int) {
+	var err error
+	defer check.Handle(&err,
+		check.OnError(func(error) { i = -1 }),
+		check.Replace(errOther, errOops),
+		check.Prefix("calling f"),
+		check.Ignore(errOops),
+	)
+	return check.Must1(f())
+}
+
+func wrap(f func() (int, error)) int {
+	var err error
+	defer check.Wrap(&err, 0)
+	return check.Must1(f())
+}
+
+func fail(f func() (int, error)) int {
+	var e error
+	defer check.Handle(&e)
+	i, err := f()
+	if err != nil {
+		check.Fail(err)
+	}
+	return i
+}
+
+func handleAnnotated(f func() (int, error)) int {
+	var err error
+	defer check.Handle(&err)
+	return check.Must1f(f())("calling %p", f)
+}
+
 func BenchmarkFailureConventional(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		call(failer)
@@ -70,12 +110,50 @@ func BenchmarkFailureHandleTransform(b *testing.B) {
 	}
 }
 
+func BenchmarkFailureHandleTransforms(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		handleTransforms(failer)
+	}
+}
+
+func BenchmarkFailureHandleAnnotated(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		handleAnnotated(failer)
+	}
+}
+
+func BenchmarkFailureWrap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		wrap(failer)
+	}
+}
+
+func BenchmarkFailureFail(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		fail(failer)
+	}
+}
+
+func BenchmarkFailureFailf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = check.Catch(func() {
+			check.Failf("oops")
+		})
+	}
+}
+
 func BenchmarkSuccessConventional(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		call(succeeder)
 	}
 }
 
+func BenchmarkSuccessBare(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bare(succeeder)
+	}
+}
+
 func BenchmarkSuccessCatch(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		catch(succeeder)
@@ -93,3 +171,42 @@ func BenchmarkSuccessHandleTransform(b *testing.B) {
 		handleTransform(succeeder)
 	}
 }
+
+func BenchmarkSuccessHandleTransforms(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		handleTransforms(succeeder)
+	}
+}
+
+func BenchmarkSuccessHandleAnnotated(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		handleAnnotated(succeeder)
+	}
+}
+
+func BenchmarkSuccessWrap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		wrap(succeeder)
+	}
+}
+
+func BenchmarkSuccessFail(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		fail(succeeder)
+	}
+}
+
+// TestFailureAllocs guards against regressions in the failure path, which
+// only allocates the Error it panics with unless transforms or Wrap allocate.
+func TestFailureAllocs(t *testing.T) {
+	for name, f := range map[string]func(func() (int, error)) int{
+		"catch":           catch,
+		"handle":          handle,
+		"handleTransform": handleTransform,
+		"fail":            fail,
+	} {
+		if n := testing.AllocsPerRun(100, func() { f(failer) }); n > 1 {
+			t.Errorf("%s: %v allocs per failure, want 1", name, n)
+		}
+	}
+}
@@ -8,12 +8,22 @@ func Fail(err error) {
 	if err == nil {
 		panic(ErrNilError)
 	}
-	panic(Error{err})
+	raise(err)
 }
 
 // Fail panics Error{fmt.Errorf(format, args...)}.
 func Failf(format string, args ...any) {
-	panic(Error{fmt.Errorf(format, args...)})
+	raiseNew(fmt.Errorf(format, args...))
+}
+
+// FailAll panics Error{err} if any of errs is not nil. If only one is, err is
+// that error, otherwise it joins them as errors.Join does.
+//
+//	check.FailAll(validateName(u.Name), validateEmail(u.Email))
+func FailAll(errs ...error) {
+	if err := join(errs...); err != nil {
+		raise(err)
+	}
 }
 
 // Pass returns r unless it is a check.Error, in which case it re-panics r.
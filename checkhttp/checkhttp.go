@@ -0,0 +1,195 @@
+// Package checkhttp adapts package check to net/http, turning check failures
+// in handlers into error responses.
+//
+//	http.Handle("/users/", checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+//		id := check.Must1(strconv.Atoi(path.Base(r.URL.Path)))
+//		u := check.Must1(db.User(r.Context(), id))
+//		check.Must(json.NewEncoder(w).Encode(u))
+//	}, checkhttp.Classify(checkhttp.Status(sql.ErrNoRows, http.StatusNotFound))))
+//
+// A failure is mapped to a status code by the first Classifier with an
+// opinion, falling back to the StatusCode of a StatusCoder in the error's
+// chain, then to the status for its check.Kind, then to 500 Internal Server
+// Error. The response is rendered as an RFC
+// 7807 problem+json document by default. If the handler has already started
+// the response, nothing more is written.
+package checkhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/goeezi/check"
+)
+
+// StatusCoder is implemented by errors that carry an HTTP status code.
+type StatusCoder interface {
+	error
+	StatusCode() int
+}
+
+// Classifier returns the HTTP status code for err, or 0 if it has no opinion.
+type Classifier func(err error) int
+
+// Status returns a Classifier that maps errors matching target, as reported by
+// errors.Is, to code.
+func Status(target error, code int) Classifier {
+	return func(err error) int {
+		if errors.Is(err, target) {
+			return code
+		}
+		return 0
+	}
+}
+
+// Renderer writes the response for err with the given status code.
+type Renderer func(w http.ResponseWriter, r *http.Request, code int, err error)
+
+// Problem renders err as an RFC 7807 problem+json document. The error message
+// is only included, as the detail, for client errors, so as not to leak
+// internal details.
+func Problem(w http.ResponseWriter, r *http.Request, code int, err error) {
+	problem := struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail,omitempty"`
+	}{Type: "about:blank", Title: http.StatusText(code), Status: code}
+	if code < 500 {
+		problem.Detail = err.Error()
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// Text renders err as plain text in the manner of http.Error. As with Problem,
+// the error message is only included for client errors.
+func Text(w http.ResponseWriter, r *http.Request, code int, err error) {
+	msg := http.StatusText(code)
+	if code < 500 {
+		msg = fmt.Sprintf("%s: %v", msg, err)
+	}
+	http.Error(w, msg, code)
+}
+
+// Option configures Handler and ErrorHandler.
+type Option func(*adapter)
+
+// Classify adds classifiers, which are consulted in order before checking for
+// a StatusCoder or check.Kind.
+func Classify(classifiers ...Classifier) Option {
+	return func(a *adapter) {
+		a.classifiers = append(a.classifiers, classifiers...)
+	}
+}
+
+// Render sets the Renderer for error responses, which defaults to Problem.
+func Render(render Renderer) Option {
+	return func(a *adapter) {
+		a.render = render
+	}
+}
+
+// Transform adds transforms, which are applied to each failure as by
+// check.Handle before it is classified. A transform that returns nil
+// suppresses the error response.
+//
+//	checkhttp.Transform(check.Log(slog.Default(), slog.LevelError))
+func Transform(transforms ...func(e error) error) Option {
+	return func(a *adapter) {
+		a.transforms = append(a.transforms, transforms...)
+	}
+}
+
+// Handler returns an http.Handler that calls h, rendering any check failure
+// it raises as an error response. Panics other than check failures propagate.
+func Handler(h func(w http.ResponseWriter, r *http.Request), opts ...Option) http.Handler {
+	return ErrorHandler(func(w http.ResponseWriter, r *http.Request) error {
+		h(w, r)
+		return nil
+	}, opts...)
+}
+
+// ErrorHandler behaves like Handler for handlers that also return errors,
+// treating a returned error as a failure.
+func ErrorHandler(h func(w http.ResponseWriter, r *http.Request) error, opts ...Option) http.Handler {
+	a := &adapter{render: Problem}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		err := check.Catch(func() { check.Must(h(rw, r)) }, a.transforms...)
+		if err != nil && !rw.started {
+			a.render(w, r, a.classify(err), err)
+		}
+	})
+}
+
+type adapter struct {
+	classifiers []Classifier
+	render      Renderer
+	transforms  []func(e error) error
+}
+
+func (a *adapter) classify(err error) int {
+	for _, classify := range a.classifiers {
+		if code := classify(err); code != 0 {
+			return code
+		}
+	}
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		return sc.StatusCode()
+	}
+	if code, ok := kindStatus[check.KindOf(err)]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// kindStatus maps kinds to status codes.
+var kindStatus = map[check.Kind]int{
+	check.Invalid:          http.StatusBadRequest,
+	check.Unauthenticated:  http.StatusUnauthorized,
+	check.PermissionDenied: http.StatusForbidden,
+	check.NotFound:         http.StatusNotFound,
+	check.Conflict:         http.StatusConflict,
+	check.Unavailable:      http.StatusServiceUnavailable,
+}
+
+// responseWriter tracks whether the response has been started.
+type responseWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+// WriteHeader starts the response unless code is informational.
+func (w *responseWriter) WriteHeader(code int) {
+	if code >= 200 {
+		w.started = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush flushes the underlying ResponseWriter if it is an http.Flusher.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.started = true
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
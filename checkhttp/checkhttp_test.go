@@ -0,0 +1,183 @@
+package checkhttp_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goeezi/check"
+	"github.com/goeezi/check/checkhttp"
+)
+
+var (
+	errOops     = errors.New("oops")
+	errNotFound = errors.New("not found")
+)
+
+type statusError struct {
+	code int
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("status %d", e.code)
+}
+
+func (e statusError) StatusCode() int {
+	return e.code
+}
+
+func serve(h http.Handler) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	w := serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ok", w.Body.String())
+
+	w = serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		check.Fail(errOops)
+	}))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t,
+		`{"type":"about:blank","title":"Internal Server Error","status":500}`,
+		w.Body.String())
+}
+
+func TestErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	w := serve(checkhttp.ErrorHandler(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("finding user: %w", statusError{http.StatusNotFound})
+	}))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t,
+		`{"type":"about:blank","title":"Not Found","status":404,"detail":"finding user: status 404"}`,
+		w.Body.String())
+
+	w = serve(checkhttp.ErrorHandler(func(w http.ResponseWriter, r *http.Request) error {
+		check.Failf("bad input")
+		return nil
+	}, checkhttp.Classify(func(error) int { return http.StatusBadRequest })))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve(checkhttp.ErrorHandler(func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	}))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	opts := []checkhttp.Option{checkhttp.Classify(
+		checkhttp.Status(errNotFound, http.StatusNotFound),
+		checkhttp.Status(errOops, http.StatusConflict),
+	)}
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{errNotFound, http.StatusNotFound},
+		{fmt.Errorf("wrapped: %w", errOops), http.StatusConflict},
+		{statusError{http.StatusTeapot}, http.StatusTeapot},
+		{check.Catch(func() { check.Failk(check.Invalid, "bad") }), http.StatusBadRequest},
+		{check.Catch(func() { check.Failk(check.Unavailable, "busy") }), http.StatusServiceUnavailable},
+		{check.Catch(func() { check.Failk(check.Internal, "bug") }), http.StatusInternalServerError},
+		{errors.New("other"), http.StatusInternalServerError},
+	} {
+		err := tc.err
+		w := serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+			check.Fail(err)
+		}, opts...))
+		assert.Equal(t, tc.code, w.Code, err)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	t.Parallel()
+
+	opts := []checkhttp.Option{
+		checkhttp.Render(checkhttp.Text),
+		checkhttp.Classify(checkhttp.Status(errNotFound, http.StatusNotFound)),
+	}
+	w := serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		check.Fail(errNotFound)
+	}, opts...))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Not Found: not found\n", w.Body.String())
+
+	w = serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		check.Fail(errOops)
+	}, opts...))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "Internal Server Error\n", w.Body.String())
+}
+
+func TestStarted(t *testing.T) {
+	t.Parallel()
+
+	w := serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		check.Fail(errOops)
+	}))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "partial")
+		check.Fail(errOops)
+	}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+
+	w = serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		check.Fail(errOops)
+	}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, w.Flushed)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestTransform(t *testing.T) {
+	t.Parallel()
+
+	var logged []error
+	w := serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		check.Fail(errOops)
+	}, checkhttp.Transform(func(e error) error {
+		logged = append(logged, e)
+		return statusError{http.StatusBadGateway}
+	})))
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Equal(t, []error{errOops}, logged)
+
+	w = serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		check.Fail(errOops)
+	}, checkhttp.Transform(check.Ignore(errOops))))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestPanic(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, 42, func() {
+		serve(checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+			panic(42)
+		}))
+	})
+}
@@ -0,0 +1,87 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package checktest
+
+import "testing"
+
+// Must1 returns a function that returns t if err is nil, otherwise it
+// calls tb.Fatal(err).
+//
+//	f := checktest.Must1(os.Open("testdata/input.txt"))(t)
+func Must1[T any](t T, err error) func(tb testing.TB) T {
+	return func(tb testing.TB) T {
+		if err != nil {
+			tb.Helper()
+			tb.Fatal(err)
+		}
+		return t
+	}
+}
+
+// Must2 returns a function that returns t1, t2 if err is nil, otherwise it
+// calls tb.Fatal(err).
+func Must2[T1, T2 any](t1 T1, t2 T2, err error) func(tb testing.TB) (T1, T2) {
+	return func(tb testing.TB) (T1, T2) {
+		if err != nil {
+			tb.Helper()
+			tb.Fatal(err)
+		}
+		return t1, t2
+	}
+}
+
+// Must3 returns a function that returns t1, t2, t3 if err is nil, otherwise it
+// calls tb.Fatal(err).
+func Must3[T1, T2, T3 any](
+	t1 T1, t2 T2, t3 T3, err error,
+) func(tb testing.TB) (T1, T2, T3) {
+	return func(tb testing.TB) (T1, T2, T3) {
+		if err != nil {
+			tb.Helper()
+			tb.Fatal(err)
+		}
+		return t1, t2, t3
+	}
+}
+
+// Must4 returns a function that returns t1, t2, t3, t4 if err is nil, otherwise
+// it calls tb.Fatal(err).
+func Must4[T1, T2, T3, T4 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, err error,
+) func(tb testing.TB) (T1, T2, T3, T4) {
+	return func(tb testing.TB) (T1, T2, T3, T4) {
+		if err != nil {
+			tb.Helper()
+			tb.Fatal(err)
+		}
+		return t1, t2, t3, t4
+	}
+}
+
+// Must5 returns a function that returns t1, t2, t3, t4, t5 if err is nil,
+// otherwise it calls tb.Fatal(err).
+func Must5[T1, T2, T3, T4, T5 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, err error,
+) func(tb testing.TB) (T1, T2, T3, T4, T5) {
+	return func(tb testing.TB) (T1, T2, T3, T4, T5) {
+		if err != nil {
+			tb.Helper()
+			tb.Fatal(err)
+		}
+		return t1, t2, t3, t4, t5
+	}
+}
+
+// Must6 returns a function that returns t1, t2, t3, t4, t5, t6 if err is nil,
+// otherwise it calls tb.Fatal(err).
+func Must6[T1, T2, T3, T4, T5, T6 any](
+	t1 T1, t2 T2, t3 T3, t4 T4, t5 T5, t6 T6, err error,
+) func(tb testing.TB) (T1, T2, T3, T4, T5, T6) {
+	return func(tb testing.TB) (T1, T2, T3, T4, T5, T6) {
+		if err != nil {
+			tb.Helper()
+			tb.Fatal(err)
+		}
+		return t1, t2, t3, t4, t5, t6
+	}
+}
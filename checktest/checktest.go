@@ -0,0 +1,94 @@
+// Package checktest adapts package check to tests, reporting check failures
+// via t.Fatal rather than letting them panic and abort the test binary.
+//
+// The MustN functions take the values under test first, so that their types
+// can be inferred, and return a function that takes t, in the manner of
+// check.MustNf and check.CollectN:
+//
+//	func TestParse(t *testing.T) {
+//		n := checktest.Must1(strconv.Atoi("42"))(t)
+//		…
+//	}
+//
+// Failures are reported at the line of the MustN call. Run does the same for
+// a block of code that calls check.Must… directly, and Fails asserts that a
+// block fails.
+package checktest
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/goeezi/check"
+)
+
+// Must calls t.Fatal(err) if err is not nil.
+//
+//	checktest.Must(t, os.WriteFile(name, data, 0o644))
+func Must(t testing.TB, err error) {
+	if err != nil {
+		t.Helper()
+		t.Fatal(err)
+	}
+}
+
+// Run calls work, reporting any check failure it raises via t.Fatal. Since
+// the failure is reported after unwinding work, the message starts with the
+// location of the Must… or Fail… call that raised it. Panics other than check
+// failures propagate.
+//
+//	checktest.Run(t, func() {
+//		cfg := check.Must1(config.Load(name))
+//		check.Must(cfg.Validate())
+//	})
+func Run(t testing.TB, work func()) {
+	t.Helper()
+	if err := catch(work); err != nil {
+		t.Fatal(located(err))
+	}
+}
+
+// Fails asserts that work raises a check failure matching target, as reported
+// by errors.Is. It reports whether the assertion held, calling t.Errorf if it
+// didn't. A nil target matches any failure.
+//
+//	checktest.Fails(t, func() { parseAll(input) }, strconv.ErrSyntax)
+func Fails(t testing.TB, work func(), target error) bool {
+	t.Helper()
+	err := catch(work)
+	switch {
+	case err == nil:
+		t.Errorf("expected a check failure matching %v, but none occurred", target)
+		return false
+	case target != nil && !errors.Is(err, target):
+		t.Errorf("expected a check failure matching %v, got: %v", target, located(err))
+		return false
+	}
+	return true
+}
+
+// catch behaves like check.Catch, but wraps the error so that it records where
+// it was raised.
+func catch(work func()) error {
+	return check.Catch(work, func(e error) error { return failure{e} })
+}
+
+// failure wraps a check failure without changing its message.
+type failure struct {
+	error
+}
+
+func (e failure) Unwrap() error {
+	return e.error
+}
+
+// located returns err's message prefixed with where it was raised, if known.
+func located(err error) string {
+	file, line, _ := check.Location(err)
+	if file == "" {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s:%d: %v", filepath.Base(file), line, err)
+}
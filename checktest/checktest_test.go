@@ -0,0 +1,147 @@
+package checktest_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goeezi/check"
+	"github.com/goeezi/check/checktest"
+)
+
+var errOops = errors.New("oops")
+
+// fakeT records failures. Like testing.T, its Fatal methods stop the calling
+// goroutine, so tests must call it via run.
+type fakeT struct {
+	testing.TB
+	helpers int
+	fatal   bool
+	msgs    []string
+}
+
+func (t *fakeT) Helper() {
+	t.helpers++
+}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.msgs = append(t.msgs, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Fatal(args ...any) {
+	t.msgs = append(t.msgs, fmt.Sprint(args...))
+	t.fatal = true
+	runtime.Goexit()
+}
+
+// run calls test with a fakeT on a new goroutine and returns the fakeT when
+// test returns or calls Fatal.
+func run(test func(t *fakeT)) *fakeT {
+	t := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		test(t)
+	}()
+	<-done
+	return t
+}
+
+// here returns the caller's line.
+func here() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestMust(t *testing.T) {
+	t.Parallel()
+
+	ft := run(func(t *fakeT) {
+		checktest.Must(t, nil)
+		checktest.Must(t, errOops)
+		t.msgs = append(t.msgs, "unreachable")
+	})
+	assert.True(t, ft.fatal)
+	assert.Equal(t, []string{"oops"}, ft.msgs)
+	assert.Equal(t, 1, ft.helpers)
+}
+
+func TestMustN(t *testing.T) {
+	t.Parallel()
+
+	var n int
+	ft := run(func(t *fakeT) {
+		n = checktest.Must1(strconv.Atoi("42"))(t)
+	})
+	assert.False(t, ft.fatal)
+	assert.Equal(t, 42, n)
+
+	ft = run(func(t *fakeT) {
+		n = checktest.Must1(strconv.Atoi("x"))(t)
+	})
+	assert.True(t, ft.fatal)
+	assert.Equal(t, []string{`strconv.Atoi: parsing "x": invalid syntax`}, ft.msgs)
+	assert.Equal(t, 1, ft.helpers)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	ft := run(func(t *fakeT) {
+		checktest.Run(t, func() {
+			check.Must1(strconv.Atoi("42"))
+		})
+	})
+	assert.False(t, ft.fatal)
+	assert.Empty(t, ft.msgs)
+
+	var line int
+	ft = run(func(t *fakeT) {
+		checktest.Run(t, func() {
+			line = here() + 1
+			check.Must(errOops)
+		})
+		t.msgs = append(t.msgs, "unreachable")
+	})
+	assert.True(t, ft.fatal)
+	assert.Equal(t, []string{fmt.Sprintf("checktest_test.go:%d: oops", line)}, ft.msgs)
+	assert.Positive(t, ft.helpers)
+}
+
+func TestRunPanic(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, 42, func() {
+		checktest.Run(&fakeT{}, func() { panic(42) })
+	})
+}
+
+func TestFails(t *testing.T) {
+	t.Parallel()
+
+	ft := run(func(t *fakeT) {
+		assert.True(t, checktest.Fails(t, func() {
+			check.Fail(fmt.Errorf("wrapped: %w", errOops))
+		}, errOops))
+		assert.True(t, checktest.Fails(t, func() { check.Fail(errOops) }, nil))
+	})
+	assert.Empty(t, ft.msgs)
+
+	var line int
+	ft = run(func(t *fakeT) {
+		assert.False(t, checktest.Fails(t, func() {}, errOops))
+		assert.False(t, checktest.Fails(t, func() {
+			line = here() + 1
+			check.Failf("other")
+		}, errOops))
+	})
+	assert.False(t, ft.fatal)
+	assert.Equal(t, []string{
+		"expected a check failure matching oops, but none occurred",
+		fmt.Sprintf("expected a check failure matching oops, got: checktest_test.go:%d: other", line),
+	}, ft.msgs)
+}
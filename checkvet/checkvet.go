@@ -0,0 +1,163 @@
+// Package checkvet provides static analyzers for code that uses package check.
+//
+// Package check relies on a convention that the type system can't enforce:
+// functions that call Must…, Fail or Failf must only be reachable from outside
+// the package via a function that traps the resulting panic with a deferred
+// check.Handle or check.Wrap, or via an enclosing check.Catch…, check.Retry…
+// or one of the checkhttp and checktest adapters. Analyzer finds exported
+// functions and methods that break this rule, directly or through unexported
+// callees in the same package, as well as goroutines whose failures would
+// crash the process. For exported functions that return an error, it suggests
+// a fix that names the results and defers check.Handle.
+// HandleAnalyzer finds calls to check.Handle and check.Wrap that can't recover
+// a failure or that lose the recovered error.
+//
+// The analyzers may be run standalone via cmd/checkvet, under go vet with
+// -vettool, or as a golangci-lint module plugin registered as "checkvet".
+package checkvet
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+// checkPath is the import path of package check.
+const checkPath = fix.CheckPath
+
+// Analyzers lists every analyzer in this package.
+var Analyzers = []*analysis.Analyzer{
+	Analyzer,
+	HandleAnalyzer,
+}
+
+// adapters lists the packages alongside check whose functions catch
+// failures on its behalf.
+var adapters = map[string]bool{
+	checkPath + "/checkhttp": true,
+	checkPath + "/checktest": true,
+}
+
+// checkFunc returns the name of the package check function called by call,
+// qualified by its receiver type if it is a method, such as "Group.Go", or by
+// its package name if it belongs to one of the adapters, such as
+// "checkhttp.Handler". It returns "" if call doesn't call one.
+func checkFunc(info *types.Info, call *ast.CallExpr) string {
+	fn, is := typeutil.Callee(info, call).(*types.Func)
+	if !is || fn.Pkg() == nil {
+		return ""
+	}
+	switch path := fn.Pkg().Path(); {
+	case path == checkPath:
+	case adapters[path] && fn.Type().(*types.Signature).Recv() == nil:
+		return fn.Pkg().Name() + "." + fn.Name()
+	default:
+		return ""
+	}
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		t := recv.Type()
+		if p, is := t.(*types.Pointer); is {
+			t = p.Elem()
+		}
+		if named, is := t.(*types.Named); is {
+			return named.Obj().Name() + "." + fn.Name()
+		}
+		return ""
+	}
+	return fn.Name()
+}
+
+// isCatching reports whether the named check function recovers failures
+// raised by its work argument.
+func isCatching(name string) bool {
+	switch name {
+	case "Group.Go", "Retry", "Retry1",
+		"checkhttp.Handler", "checkhttp.ErrorHandler",
+		"checktest.Run", "checktest.Fails":
+		return true
+	}
+	return strings.HasPrefix(name, "Catch")
+}
+
+// workArg returns the index of the work argument of the named catching
+// function.
+func workArg(name string) int {
+	switch {
+	case strings.HasPrefix(name, "CatchCtx"), name == "checktest.Run", name == "checktest.Fails":
+		return 1
+	case name == "Retry", name == "Retry1":
+		return 2
+	}
+	return 0
+}
+
+// isHandling reports whether the named check function recovers failures when
+// deferred.
+func isHandling(name string) bool {
+	switch name {
+	case "Handle", "HandleAll", "Wrap", "Scope.Handle":
+		return true
+	}
+	return false
+}
+
+// deferredHandler returns the first statement in body, excluding nested
+// function literals, that defers a handling function such as check.Handle.
+func deferredHandler(info *types.Info, body *ast.BlockStmt) *ast.DeferStmt {
+	var found *ast.DeferStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			if found == nil && isHandling(checkFunc(info, n.Call)) {
+				found = n
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// funcName returns the name of decl qualified by its receiver type, if any.
+func funcName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	return recvName(decl.Recv.List[0].Type) + "." + decl.Name.Name
+}
+
+// recvName returns the base type name of a receiver type expression.
+func recvName(expr ast.Expr) string {
+	for {
+		switch x := expr.(type) {
+		case *ast.StarExpr:
+			expr = x.X
+		case *ast.ParenExpr:
+			expr = x.X
+		case *ast.IndexExpr:
+			expr = x.X
+		case *ast.IndexListExpr:
+			expr = x.X
+		case *ast.Ident:
+			return x.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// isAPI reports whether decl is callable from outside its package, i.e., it
+// is an exported function or an exported method of an exported type.
+func isAPI(decl *ast.FuncDecl) bool {
+	if !ast.IsExported(decl.Name.Name) {
+		return false
+	}
+	return decl.Recv == nil || len(decl.Recv.List) == 0 ||
+		ast.IsExported(recvName(decl.Recv.List[0].Type))
+}
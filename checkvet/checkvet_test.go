@@ -0,0 +1,27 @@
+package checkvet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/goeezi/check/checkvet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(t, analysistest.TestData(), checkvet.Analyzer, "escape")
+}
+
+func TestHandleAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(t, analysistest.TestData(), checkvet.HandleAnalyzer, "handle")
+}
+
+func TestAnalyzerFixes(t *testing.T) {
+	t.Parallel()
+
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), checkvet.Analyzer, "fix")
+}
@@ -0,0 +1,36 @@
+// Command checkify rewrites conventional error handling to use package check.
+//
+// It converts error checks of the form
+//
+//	x, err := f()
+//	if err != nil {
+//		return 0, err
+//	}
+//
+// to x := check.Must1(f()), names the enclosing function's results and defers
+// check.Handle. Errors wrapped with fmt.Errorf("…: %w", err) become a Handle
+// transform. Error checks whose branch does anything other than return zero
+// values and the error are left untouched and reported on standard error.
+//
+// Usage:
+//
+//	checkify [-l] [-w] [packages]
+//
+// By default, checkify prints the rewritten files to standard output.
+package main
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/goeezi/check/checkvet/internal/cli"
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+func main() {
+	cli.Main("checkify", func(pkg *packages.Package, file *ast.File, src []byte) ([]analysis.TextEdit, []cli.Skip) {
+		return rewrite(pkg.Fset, pkg.TypesInfo, file, src, fix.MaxArity(pkg.Types))
+	})
+}
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/goeezi/check/checkvet/internal/cli"
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+// candidate is an error check that can be rewritten to a MustN call.
+type candidate struct {
+	assign *ast.AssignStmt
+	ifs    *ast.IfStmt
+	err    types.Object
+	wrap   string // format passed to fmt.Errorf, if the error is wrapped
+}
+
+// start returns the position of the first statement replaced by c.
+func (c *candidate) start() token.Pos {
+	if c.ifs.Init != nil {
+		return c.ifs.Pos()
+	}
+	return c.assign.Pos()
+}
+
+type rewriter struct {
+	fset     *token.FileSet
+	info     *types.Info
+	file     *ast.File
+	src      []byte
+	maxArity int // the highest N for which package check provides MustN
+	edits    []analysis.TextEdit
+	skips    []cli.Skip
+}
+
+// rewrite returns the edits that convert the error checks in file to MustN
+// calls, for N up to maxArity, and the checks it left untouched.
+func rewrite(
+	fset *token.FileSet,
+	info *types.Info,
+	file *ast.File,
+	src []byte,
+	maxArity int,
+) ([]analysis.TextEdit, []cli.Skip) {
+	r := &rewriter{fset: fset, info: info, file: file, src: src, maxArity: maxArity}
+	deferred := map[*ast.FuncLit]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Body != nil {
+				r.function(n.Type, n.Body, false)
+			}
+		case *ast.DeferStmt:
+			if lit, is := n.Call.Fun.(*ast.FuncLit); is {
+				deferred[lit] = true
+			}
+		case *ast.FuncLit:
+			r.function(n.Type, n.Body, deferred[n])
+		}
+		return true
+	})
+	return r.edits, r.skips
+}
+
+// function rewrites the error checks in the body of a function with type
+// ftype. Those of a deferred function literal without an error result aren't
+// reported, since nothing could receive the error.
+func (r *rewriter) function(ftype *ast.FuncType, body *ast.BlockStmt, deferred bool) {
+	var cands []*candidate
+	hasError := ftype.Results != nil &&
+		fix.IsError(r.info.TypeOf(ftype.Results.List[len(ftype.Results.List)-1].Type))
+	if !hasError && deferred {
+		return
+	}
+	walkBlocks(body, func(stmts []ast.Stmt) {
+		for i, stmt := range stmts {
+			ifs, is := stmt.(*ast.IfStmt)
+			if !is || r.errCheck(ifs.Cond) == nil {
+				continue
+			}
+			if !hasError {
+				r.skip(ifs, "function has no error result")
+				continue
+			}
+			var prev ast.Stmt
+			if i > 0 {
+				prev = stmts[i-1]
+			}
+			if c := r.candidate(prev, ifs); c != nil {
+				cands = append(cands, c)
+			}
+		}
+	})
+	if len(cands) == 0 {
+		return
+	}
+
+	// A single Handle transform can only carry one wrapping message.
+	wraps := map[string]bool{}
+	for _, c := range cands {
+		wraps[c.wrap] = true
+	}
+	transform := ""
+	if len(wraps) == 1 && !wraps[""] {
+		for wrap := range wraps {
+			transform = wrap
+		}
+	} else if len(wraps) > 1 {
+		cands = r.filter(cands, func(c *candidate) string {
+			if c.wrap != "" {
+				return "wrapped error differs from other checks in the function"
+			}
+			return ""
+		})
+	}
+
+	// Handle returns the current values of named results, which the error
+	// branches replaced with zero values.
+	if r.resultsUsed(ftype, body) {
+		cands = r.filter(cands, func(c *candidate) string {
+			return "named results may be non-zero when the check fails"
+		})
+	}
+
+	// An existing handler must recover the new failures exactly as the error
+	// checks returned them.
+	handler, name := r.handler(body)
+	switch {
+	case handler == nil:
+	case name != "Handle":
+		cands = r.filter(cands, func(c *candidate) string {
+			return "function already defers check." + name
+		})
+	case len(handler.Call.Args) > 1:
+		cands = r.filter(cands, func(c *candidate) string {
+			return "function already defers check.Handle with transforms"
+		})
+	default:
+		cands = r.filter(cands, func(c *candidate) string {
+			if c.start() < handler.Pos() {
+				return "error check comes before the deferred check.Handle"
+			}
+			return ""
+		})
+	}
+	// Other deferred calls run before the deferred check.Handle, so they
+	// would see the error result before Handle sets it and would recover the
+	// failures themselves.
+	var errResult types.Object
+	if last := ftype.Results.List[len(ftype.Results.List)-1]; len(last.Names) > 0 {
+		errResult = r.info.Defs[last.Names[len(last.Names)-1]]
+	}
+	if why := fix.DeferConflict(r.info, body, handler, errResult); why != "" {
+		cands = r.filter(cands, func(c *candidate) string {
+			return why
+		})
+	}
+	if handler != nil && transform != "" {
+		cands = r.filter(cands, func(c *candidate) string {
+			return "wrapped error in a function that already defers check.Handle"
+		})
+	}
+
+	// The error variable must not be needed anywhere else once its checks are
+	// gone.
+	cands = r.filter(cands, func(c *candidate) string {
+		if r.usedOutside(body, c.err, cands) {
+			return c.err.Name() + " is used outside its error checks"
+		}
+		return ""
+	})
+	if len(cands) == 0 {
+		return
+	}
+
+	qual, edit := fix.Import(r.file, fix.CheckPath)
+	for _, c := range cands {
+		r.replace(c, qual)
+	}
+	if handler == nil {
+		var transforms []string
+		if transform != "" {
+			transforms = append(transforms,
+				fmt.Sprintf("func(e error) error {\nreturn fmt.Errorf(%s, e)\n}", transform))
+		}
+		edits, _ := fix.Handle(r.fset, r.info, r.file, ftype, body, transforms...)
+		r.edits = append(r.edits, edits...)
+	} else if edit != nil {
+		r.edits = append(r.edits, *edit)
+	}
+}
+
+// candidate returns the rewritable error check formed by ifs and the
+// statement preceding it, if any. Otherwise it records why not and returns
+// nil.
+func (r *rewriter) candidate(prev ast.Stmt, ifs *ast.IfStmt) *candidate {
+	errObj := r.errCheck(ifs.Cond)
+	assign, is := ifs.Init.(*ast.AssignStmt)
+	if ifs.Init == nil {
+		assign, is = prev.(*ast.AssignStmt)
+	}
+	if !is || !r.assigns(assign, errObj) {
+		r.skip(ifs, "error isn't the result of the call immediately before the check")
+		return nil
+	}
+	if len(assign.Lhs)-1 > r.maxArity {
+		r.skip(ifs, fmt.Sprintf("call returns more than %d values and an error", r.maxArity))
+		return nil
+	}
+	if ifs.Else != nil {
+		r.skip(ifs, "error check has an else branch")
+		return nil
+	}
+	var ret *ast.ReturnStmt
+	if len(ifs.Body.List) == 1 {
+		ret, _ = ifs.Body.List[0].(*ast.ReturnStmt)
+	}
+	if ret == nil || len(ret.Results) == 0 {
+		r.skip(ifs, "error branch does more than return")
+		return nil
+	}
+	for _, result := range ret.Results[:len(ret.Results)-1] {
+		if !r.isZero(result) {
+			r.skip(ifs, "error branch returns non-zero values")
+			return nil
+		}
+	}
+	c := &candidate{assign: assign, ifs: ifs, err: errObj}
+	switch last := ret.Results[len(ret.Results)-1].(type) {
+	case *ast.Ident:
+		if r.info.Uses[last] == errObj {
+			return c
+		}
+	case *ast.CallExpr:
+		if c.wrap = r.wrapFormat(last, errObj); c.wrap != "" {
+			return c
+		}
+	}
+	r.skip(ifs, "error branch returns a different error")
+	return nil
+}
+
+// errCheck returns the error variable tested by cond if it has the form
+// err != nil.
+func (r *rewriter) errCheck(cond ast.Expr) types.Object {
+	bin, is := cond.(*ast.BinaryExpr)
+	if !is || bin.Op != token.NEQ || !r.info.Types[bin.Y].IsNil() {
+		return nil
+	}
+	id, is := bin.X.(*ast.Ident)
+	if !is {
+		return nil
+	}
+	if obj := r.info.Uses[id]; obj != nil && fix.IsError(obj.Type()) {
+		if _, is := obj.(*types.Var); is {
+			return obj
+		}
+	}
+	return nil
+}
+
+// assigns reports whether assign assigns the results of a single call to
+// variables, the last of which is errObj.
+func (r *rewriter) assigns(assign *ast.AssignStmt, errObj types.Object) bool {
+	if len(assign.Rhs) != 1 {
+		return false
+	}
+	if _, is := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr); !is {
+		return false
+	}
+	for _, lhs := range assign.Lhs {
+		if _, is := lhs.(*ast.Ident); !is {
+			return false
+		}
+	}
+	last := assign.Lhs[len(assign.Lhs)-1].(*ast.Ident)
+	return r.info.ObjectOf(last) == errObj
+}
+
+// wrapFormat returns the quoted format of call if it has the form
+// fmt.Errorf("…%w…", err).
+func (r *rewriter) wrapFormat(call *ast.CallExpr, errObj types.Object) string {
+	fn, is := typeutil.Callee(r.info, call).(*types.Func)
+	if !is || fn.FullName() != "fmt.Errorf" || len(call.Args) != 2 {
+		return ""
+	}
+	format, is := call.Args[0].(*ast.BasicLit)
+	if !is || format.Kind != token.STRING {
+		return ""
+	}
+	if s, err := strconv.Unquote(format.Value); err != nil || strings.Count(s, "%w") != 1 {
+		return ""
+	}
+	if id, is := call.Args[1].(*ast.Ident); !is || r.info.Uses[id] != errObj {
+		return ""
+	}
+	return format.Value
+}
+
+// isZero reports whether expr is the zero value of its type.
+func (r *rewriter) isZero(expr ast.Expr) bool {
+	tv := r.info.Types[expr]
+	switch {
+	case tv.IsNil():
+		return true
+	case tv.Value != nil:
+		switch tv.Value.Kind() {
+		case constant.Bool:
+			return !constant.BoolVal(tv.Value)
+		case constant.String:
+			return constant.StringVal(tv.Value) == ""
+		case constant.Int, constant.Float, constant.Complex:
+			return constant.Sign(tv.Value) == 0
+		}
+	}
+	if lit, is := ast.Unparen(expr).(*ast.CompositeLit); is && len(lit.Elts) == 0 {
+		switch tv.Type.Underlying().(type) {
+		case *types.Struct, *types.Array:
+			return true
+		}
+	}
+	return false
+}
+
+// handler returns the statement in body that defers check.Handle,
+// check.HandleAll or check.Wrap, if any, and the name of the function.
+func (r *rewriter) handler(body *ast.BlockStmt) (*ast.DeferStmt, string) {
+	for _, stmt := range body.List {
+		if d, is := stmt.(*ast.DeferStmt); is {
+			fn, is := typeutil.Callee(r.info, d.Call).(*types.Func)
+			if is && fn.Pkg() != nil && fn.Pkg().Path() == fix.CheckPath {
+				switch fn.Name() {
+				case "Handle", "HandleAll", "Wrap":
+					return d, fn.Name()
+				}
+			}
+		}
+	}
+	return nil, ""
+}
+
+// usedOutside reports whether obj is referenced in body outside the error
+// checks of cands.
+func (r *rewriter) usedOutside(body *ast.BlockStmt, obj types.Object, cands []*candidate) bool {
+	used := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		id, is := n.(*ast.Ident)
+		if !is || r.info.ObjectOf(id) != obj {
+			return !used
+		}
+		for _, c := range cands {
+			if c.err == obj && c.start() <= id.Pos() && id.End() <= c.ifs.End() {
+				return true
+			}
+		}
+		used = true
+		return false
+	})
+	return used
+}
+
+// resultsUsed reports whether body refers to any named result of ftype other
+// than the final error.
+func (r *rewriter) resultsUsed(ftype *ast.FuncType, body *ast.BlockStmt) bool {
+	results := map[types.Object]bool{}
+	fields := ftype.Results.List
+	for i, field := range fields {
+		for j, name := range field.Names {
+			if i < len(fields)-1 || j < len(field.Names)-1 {
+				results[r.info.Defs[name]] = true
+			}
+		}
+	}
+	used := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if id, is := n.(*ast.Ident); is && results[r.info.Uses[id]] {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// filter returns the candidates for which reason returns "", recording the
+// others as skipped.
+func (r *rewriter) filter(cands []*candidate, reason func(c *candidate) string) []*candidate {
+	var kept []*candidate
+	for _, c := range cands {
+		if why := reason(c); why != "" {
+			r.skip(c.ifs, why)
+		} else {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// replace rewrites c as a call to MustN.
+func (r *rewriter) replace(c *candidate, qual string) {
+	vals := c.assign.Lhs[:len(c.assign.Lhs)-1]
+	must := qual + "Must"
+	if len(vals) > 0 {
+		must += strconv.Itoa(len(vals))
+	}
+	call := c.assign.Rhs[0]
+	text := must + "(" + r.source(call) + ")"
+
+	// Variables declared in an if statement's init are only in scope within
+	// the if statement, so they can be dropped.
+	if c.ifs.Init == nil && !allBlank(vals) {
+		tok := c.assign.Tok
+		if tok == token.DEFINE && !r.definesAny(vals) {
+			tok = token.ASSIGN
+		}
+		names := make([]string, len(vals))
+		for i, val := range vals {
+			names[i] = val.(*ast.Ident).Name
+		}
+		text = strings.Join(names, ", ") + " " + tok.String() + " " + text
+	}
+	r.edits = append(r.edits, analysis.TextEdit{Pos: c.start(), End: c.ifs.End(), NewText: []byte(text)})
+}
+
+func (r *rewriter) definesAny(vals []ast.Expr) bool {
+	for _, val := range vals {
+		if id := val.(*ast.Ident); id.Name != "_" && r.info.Defs[id] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func allBlank(vals []ast.Expr) bool {
+	for _, val := range vals {
+		if val.(*ast.Ident).Name != "_" {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *rewriter) source(node ast.Node) string {
+	tf := r.fset.File(node.Pos())
+	return string(r.src[tf.Offset(node.Pos()):tf.Offset(node.End())])
+}
+
+func (r *rewriter) skip(node ast.Node, reason string) {
+	r.skips = append(r.skips, cli.Skip{Pos: node.Pos(), Reason: reason})
+}
+
+// walkBlocks calls f with every statement list in body, excluding those of
+// nested function literals.
+func walkBlocks(body *ast.BlockStmt, f func([]ast.Stmt)) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BlockStmt:
+			f(n.List)
+		case *ast.CaseClause:
+			f(n.Body)
+		case *ast.CommClause:
+			f(n.Body)
+		}
+		return true
+	})
+}
@@ -0,0 +1,428 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+// stubImporter imports package check from the analyzer test stub and
+// everything else from source.
+type stubImporter struct {
+	fset  *token.FileSet
+	check *types.Package
+	std   types.Importer
+}
+
+func (imp *stubImporter) Import(path string) (*types.Package, error) {
+	if path != fix.CheckPath {
+		return imp.std.Import(path)
+	}
+	if imp.check == nil {
+		name := filepath.Join("..", "..", "testdata", "src", fix.CheckPath, "check.go")
+		file, err := parser.ParseFile(imp.fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		conf := types.Config{Importer: imp.std}
+		if imp.check, err = conf.Check(path, imp.fset, []*ast.File{file}, nil); err != nil {
+			return nil, err
+		}
+	}
+	return imp.check, nil
+}
+
+// checkify rewrites src, returning the result and the skipped reasons.
+func checkify(t *testing.T, src string) (string, []string) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "a.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{Importer: &stubImporter{fset: fset, std: importer.ForCompiler(fset, "source", nil)}}
+	pkg, err := conf.Check("a", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	edits, skips := rewrite(fset, info, file, []byte(src), fix.MaxArity(pkg))
+	var reasons []string
+	for _, s := range skips {
+		reasons = append(reasons, s.Reason)
+	}
+	if len(edits) == 0 {
+		return src, reasons
+	}
+	out, err := fix.Apply(fset.File(file.Pos()), []byte(src), edits)
+	require.NoError(t, err)
+	return string(out), reasons
+}
+
+func TestRewrite(t *testing.T) {
+	t.Parallel()
+
+	out, skips := checkify(t, `package a
+
+import (
+	"os"
+	"strconv"
+)
+
+func Parse(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.Remove(s); err != nil {
+		return 0, err
+	}
+	_, err = strconv.ParseBool(s)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+`)
+	assert.Empty(t, skips)
+	assert.Equal(t, `package a
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+func Parse(s string) (_ int, e error) {
+	defer check.Handle(&e)
+	n := check.Must1(strconv.Atoi(s))
+	check.Must(os.Remove(s))
+	check.Must1(strconv.ParseBool(s))
+	return n, nil
+}
+`, out)
+}
+
+func TestRewriteWrapped(t *testing.T) {
+	t.Parallel()
+
+	out, skips := checkify(t, `package a
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type point struct{ x, y int }
+
+func parse(x, y string) (p *point, v point, err error) {
+	a, err := strconv.Atoi(x)
+	if err != nil {
+		return nil, point{}, fmt.Errorf("parsing point: %w", err)
+	}
+	b, err := strconv.Atoi(y)
+	if err != nil {
+		return nil, point{}, fmt.Errorf("parsing point: %w", err)
+	}
+	return &point{a, b}, point{a, b}, nil
+}
+`)
+	assert.Empty(t, skips)
+	assert.Equal(t, `package a
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+type point struct{ x, y int }
+
+func parse(x, y string) (p *point, v point, err error) {
+	defer check.Handle(&err, func(e error) error {
+		return fmt.Errorf("parsing point: %w", e)
+	})
+	a := check.Must1(strconv.Atoi(x))
+	b := check.Must1(strconv.Atoi(y))
+	return &point{a, b}, point{a, b}, nil
+}
+`, out)
+}
+
+func TestRewriteSkips(t *testing.T) {
+	t.Parallel()
+
+	src := `package a
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+)
+
+func logs(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		log.Print(err)
+		return 0, err
+	}
+	return n, nil
+}
+
+func nonZero(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return -1, err
+	}
+	return n, nil
+}
+
+func reused(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	log.Print(err)
+	return n, nil
+}
+
+func other(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad %q: %v", s, err)
+	}
+	return n, nil
+}
+
+func named(s string) (n int, err error) {
+	n = 42
+	m, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	return n + m, nil
+}
+
+func noError(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+`
+	out, skips := checkify(t, src)
+	assert.Equal(t, src, out)
+	assert.Equal(t, []string{
+		"error branch does more than return",
+		"error branch returns non-zero values",
+		"err is used outside its error checks",
+		"error branch returns a different error",
+		"named results may be non-zero when the check fails",
+		"function has no error result",
+	}, skips)
+}
+
+func TestRewriteMixedWrapping(t *testing.T) {
+	t.Parallel()
+
+	out, skips := checkify(t, `package a
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func parse(x, y string) (int, error) {
+	a, err := strconv.Atoi(x)
+	if err != nil {
+		return 0, err
+	}
+	b, err2 := strconv.Atoi(y)
+	if err2 != nil {
+		return 0, fmt.Errorf("parsing y: %w", err2)
+	}
+	return a + b, nil
+}
+`)
+	assert.Equal(t, []string{"wrapped error differs from other checks in the function"}, skips)
+	assert.Equal(t, `package a
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+func parse(x, y string) (_ int, e error) {
+	defer check.Handle(&e)
+	a := check.Must1(strconv.Atoi(x))
+	b, err2 := strconv.Atoi(y)
+	if err2 != nil {
+		return 0, fmt.Errorf("parsing y: %w", err2)
+	}
+	return a + b, nil
+}
+`, out)
+}
+
+func TestRewriteExistingHandler(t *testing.T) {
+	t.Parallel()
+
+	src := `package a
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+func Before(s string) (_ int, e error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	defer check.Handle(&e)
+	return n, nil
+}
+
+func Transformed(s string) (_ int, e error) {
+	defer check.Handle(&e, func(e error) error {
+		if errors.Is(e, strconv.ErrRange) {
+			return nil
+		}
+		return e
+	})
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func Wrapped(s string) (_ int, e error) {
+	defer check.Wrap(&e, 0)
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func All(s string) (_ int, e error) {
+	defer check.HandleAll(&e)
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+`
+	out, skips := checkify(t, src)
+	assert.Equal(t, src, out)
+	assert.Equal(t, []string{
+		"error check comes before the deferred check.Handle",
+		"function already defers check.Handle with transforms",
+		"function already defers check.Wrap",
+		"function already defers check.HandleAll",
+	}, skips)
+}
+
+func TestRewriteMaxArity(t *testing.T) {
+	t.Parallel()
+
+	// The analyzer test stub of package check only provides Must1…Must3.
+	src := `package a
+
+import "github.com/goeezi/check"
+
+func four() (int, int, int, int, error) {
+	return 1, 2, 3, 4, nil
+}
+
+func Sum() (_ int, e error) {
+	defer check.Handle(&e)
+	a, b, c, d, err := four()
+	if err != nil {
+		return 0, err
+	}
+	return a + b + c + d, nil
+}
+`
+	out, skips := checkify(t, src)
+	assert.Equal(t, src, out)
+	assert.Equal(t, []string{"call returns more than 3 values and an error"}, skips)
+}
+
+func TestRewriteOtherDefers(t *testing.T) {
+	t.Parallel()
+
+	src := `package a
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+)
+
+type tx struct{}
+
+func (tx) Rollback() error { return nil }
+
+func RollsBack(t tx, s string) (_ int, err error) {
+	defer func() {
+		if err != nil {
+			t.Rollback()
+		}
+	}()
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func Recovers(s string) (_ int, e error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func Logs(s string) {
+	defer func() {
+		_, err := strconv.Atoi(s)
+		if err != nil {
+			log.Print(err)
+		}
+	}()
+}
+`
+	out, skips := checkify(t, src)
+	assert.Equal(t, src, out)
+	assert.Equal(t, []string{
+		"function defers a call that uses the error result",
+		"function defers a call that recovers panics",
+	}, skips)
+}
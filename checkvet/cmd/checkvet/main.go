@@ -0,0 +1,16 @@
+// Command checkvet runs the checkvet analyzers, either standalone or as a vet
+// tool:
+//
+//	checkvet ./...
+//	go vet -vettool=$(which checkvet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/goeezi/check/checkvet"
+)
+
+func main() {
+	multichecker.Main(checkvet.Analyzers...)
+}
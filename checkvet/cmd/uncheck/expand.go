@@ -0,0 +1,822 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/goeezi/check/checkvet/internal/cli"
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+// site is a call to a failing check function.
+type site struct {
+	call    *ast.CallExpr
+	name    string
+	results int // the number of results of the called function
+}
+
+// arity returns N for MustN, 0 for Must and -1 for Fail and Failf. N is taken
+// from the signature of the called MustN, so sites follow whichever arities
+// the loaded package check provides.
+func (s site) arity() int {
+	if s.mustN() {
+		return s.results
+	}
+	if s.name == "Must" {
+		return 0
+	}
+	return -1
+}
+
+// mustN reports whether s calls MustN for some N.
+func (s site) mustN() bool {
+	n, err := strconv.Atoi(strings.TrimPrefix(s.name, "Must"))
+	return strings.HasPrefix(s.name, "Must") && err == nil && n > 0
+}
+
+// expandable reports whether s calls Must, MustN, Fail or Failf.
+func (s site) expandable() bool {
+	switch s.name {
+	case "Must", "Fail", "Failf":
+		return true
+	}
+	return s.mustN()
+}
+
+// stmtSites groups the sites in a single statement.
+type stmtSites struct {
+	stmt  ast.Stmt
+	sites []site
+}
+
+type expander struct {
+	fset  *token.FileSet
+	info  *types.Info
+	file  *ast.File
+	src   []byte
+	decls map[*types.Func]*ast.FuncDecl
+	fails map[*types.Func]bool // memo for canFail
+	edits []analysis.TextEdit
+	skips []cli.Skip
+}
+
+// function state while expanding a single declaration.
+type function struct {
+	*expander
+	decl       *ast.FuncDecl
+	errName    string
+	transforms []ast.Expr
+	hoisted    map[ast.Expr]string // transforms evaluated once, at the handler
+	handlerPos token.Pos
+	results    []string // values returned on failure, excluding the error
+	used       map[string]bool
+	edits      []analysis.TextEdit
+}
+
+// expand returns the edits that replace check calls with conventional error
+// handling in the declarations of file selected by want.
+func expand(
+	fset *token.FileSet,
+	info *types.Info,
+	files []*ast.File,
+	file *ast.File,
+	src []byte,
+	want func(decl *ast.FuncDecl) bool,
+) ([]analysis.TextEdit, []cli.Skip) {
+	x := &expander{
+		fset:  fset,
+		info:  info,
+		file:  file,
+		src:   src,
+		decls: map[*types.Func]*ast.FuncDecl{},
+		fails: map[*types.Func]bool{},
+	}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if decl, is := decl.(*ast.FuncDecl); is && decl.Body != nil {
+				if fn, is := info.Defs[decl.Name].(*types.Func); is {
+					x.decls[fn] = decl
+				}
+			}
+		}
+	}
+	for _, decl := range file.Decls {
+		if decl, is := decl.(*ast.FuncDecl); is && decl.Body != nil && want(decl) {
+			x.function(decl)
+		}
+	}
+	return x.edits, x.skips
+}
+
+func (x *expander) function(decl *ast.FuncDecl) {
+	handler := x.handler(decl.Body)
+	if handler == nil {
+		if len(x.sites(decl.Body)) > 0 {
+			x.skip(decl.Name, "function doesn't defer check.Handle")
+		}
+		return
+	}
+	f := &function{expander: x, decl: decl, used: map[string]bool{}}
+	if reason := f.init(handler); reason != "" {
+		x.skip(handler, reason)
+		return
+	}
+	if reason := f.expand(); reason != "" {
+		x.skip(decl.Name, reason)
+		return
+	}
+
+	// Remove the deferred Handle along with the space up to the next
+	// statement, leaving the transforms that must still be evaluated there.
+	end := decl.Body.Rbrace
+	for i, stmt := range decl.Body.List {
+		if stmt == ast.Stmt(handler) && i+1 < len(decl.Body.List) {
+			end = decl.Body.List[i+1].Pos()
+		}
+	}
+	var hoisted strings.Builder
+	for _, t := range f.transforms {
+		if name, is := f.hoisted[t]; is {
+			fmt.Fprintf(&hoisted, "%s := %s\n", name, f.source(t))
+		}
+	}
+	f.edits = append(f.edits, analysis.TextEdit{Pos: handler.Pos(), End: end, NewText: []byte(hoisted.String())})
+	x.edits = append(x.edits, f.edits...)
+}
+
+// handler returns the statement in body that defers check.Handle,
+// check.HandleAll or check.Wrap, if any.
+func (x *expander) handler(body *ast.BlockStmt) *ast.DeferStmt {
+	for _, stmt := range body.List {
+		if d, is := stmt.(*ast.DeferStmt); is {
+			if name := x.checkFunc(d.Call); name == "Handle" || name == "HandleAll" || name == "Wrap" {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// init validates the deferred handler and records what failures return. It
+// returns why the function can't be expanded, if it can't.
+func (f *function) init(handler *ast.DeferStmt) string {
+	switch name := f.checkFunc(handler.Call); name {
+	case "HandleAll":
+		return "check.HandleAll recovers other panics, which conventional code doesn't"
+	case "Wrap":
+		return "check.Wrap records a stack trace, which conventional code doesn't"
+	}
+	args := handler.Call.Args
+	if handler.Call.Ellipsis.IsValid() {
+		return "check.Handle transforms are passed as a slice"
+	}
+	addr, is := ast.Unparen(args[0]).(*ast.UnaryExpr)
+	if !is || addr.Op != token.AND {
+		return "check.Handle isn't given a pointer to the error result"
+	}
+	id, is := ast.Unparen(addr.X).(*ast.Ident)
+	if !is {
+		return "check.Handle isn't given a pointer to the error result"
+	}
+	errObj := f.info.Uses[id]
+	fields := f.decl.Type.Results
+	if fields == nil {
+		return "function has no results"
+	}
+	for i, field := range fields.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{nil}
+		}
+		for j, name := range names {
+			if i == len(fields.List)-1 && j == len(names)-1 {
+				if name == nil || f.info.Defs[name] != errObj {
+					return "check.Handle isn't given a pointer to the error result"
+				}
+				continue
+			}
+			if name != nil && name.Name != "_" {
+				f.results = append(f.results, name.Name)
+			} else {
+				f.results = append(f.results, f.zero(field.Type))
+			}
+		}
+	}
+	f.errName = id.Name
+	f.transforms = args[1:]
+	f.handlerPos = handler.Pos()
+
+	// Failure paths return errName, which is only nil if nothing else in the
+	// body assigns it.
+	assigned := false
+	ast.Inspect(f.decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if id, is := lhs.(*ast.Ident); is && f.info.ObjectOf(id) == errObj {
+					assigned = true
+				}
+			}
+		case *ast.UnaryExpr:
+			if id, is := n.X.(*ast.Ident); is && n.Op == token.AND && n != addr && f.info.Uses[id] == errObj {
+				assigned = true
+			}
+		}
+		return !assigned
+	})
+	if assigned {
+		return id.Name + " is assigned in the function body"
+	}
+	if reason := fix.DeferConflict(f.info, f.decl.Body, handler, errObj); reason != "" {
+		return reason
+	}
+
+	ast.Inspect(f.decl, func(n ast.Node) bool {
+		if id, is := n.(*ast.Ident); is {
+			f.used[id.Name] = true
+		}
+		return true
+	})
+
+	// Deferring Handle evaluates the transforms once, so any that could
+	// yield a different function each time, such as check.Log(…), are
+	// assigned to variables in its place.
+	f.hoisted = map[ast.Expr]string{}
+	for _, t := range f.transforms {
+		if !f.static(t) {
+			f.hoisted[t] = f.fresh("transform")
+		}
+	}
+	return ""
+}
+
+// static reports whether evaluating the transform t always yields the same
+// function: a function literal or a declared function.
+func (f *function) static(t ast.Expr) bool {
+	switch t := ast.Unparen(t).(type) {
+	case *ast.FuncLit:
+		return true
+	case *ast.Ident:
+		_, is := f.info.Uses[t].(*types.Func)
+		return is
+	case *ast.SelectorExpr:
+		_, is := f.info.Uses[t.Sel].(*types.Func)
+		return is && f.info.Selections[t] == nil
+	}
+	return false
+}
+
+// sites returns the failing check calls in body, excluding those in nested
+// function literals.
+func (x *expander) sites(body *ast.BlockStmt) []site {
+	var sites []site
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if name := x.checkFunc(n); fix.Failing(name) {
+				s := site{call: n, name: name}
+				if sig, is := x.info.TypeOf(n.Fun).(*types.Signature); is {
+					s.results = sig.Results().Len()
+				}
+				sites = append(sites, s)
+			}
+		}
+		return true
+	})
+	return sites
+}
+
+// expand computes the edits for f's body. It returns why the function can't
+// be expanded, if it can't.
+func (f *function) expand() string {
+	if reason := f.hidden(f.decl.Body); reason != "" {
+		return reason
+	}
+	var groups []*stmtSites
+	byStmt := map[ast.Stmt]*stmtSites{}
+	for _, s := range f.sites(f.decl.Body) {
+		if !s.expandable() {
+			return "check." + s.name + " isn't supported"
+		}
+		if s.call.Pos() < f.handlerPos {
+			return "check call before the deferred check.Handle"
+		}
+		stmt, reason := f.container(s.call)
+		if reason != "" {
+			return reason
+		}
+		g := byStmt[stmt]
+		if g == nil {
+			g = &stmtSites{stmt: stmt}
+			byStmt[stmt] = g
+			groups = append(groups, g)
+		}
+		g.sites = append(g.sites, s)
+	}
+	for _, g := range groups {
+		if reason := f.statement(g); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// hidden returns a reason if failures in body could be raised somewhere other
+// than a direct check call, since those would no longer be recovered.
+func (f *function) hidden(body *ast.BlockStmt) string {
+	reason := ""
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			if f.handler(n.Body) == nil && len(f.sites(n.Body)) > 0 {
+				reason = "check call inside a function literal"
+			}
+			return false
+		case *ast.DeferStmt:
+			if len(f.sites(&ast.BlockStmt{List: []ast.Stmt{n}})) > 0 {
+				reason = "deferred check call"
+			}
+		case *ast.CallExpr:
+			if strings.HasPrefix(f.checkFunc(n), "Catch") {
+				// Catch recovers its own failures.
+				return false
+			}
+			if fn := typeutil.StaticCallee(f.info, n); fn != nil && f.canFail(fn.Origin()) {
+				reason = "calls " + fn.Name() + ", which can fail via package check"
+			}
+		}
+		return reason == ""
+	})
+	return reason
+}
+
+// canFail reports whether fn is declared in the package and can let a check
+// failure escape.
+func (x *expander) canFail(fn *types.Func) bool {
+	if fails, seen := x.fails[fn]; seen {
+		return fails
+	}
+	decl := x.decls[fn]
+	if decl == nil {
+		return false
+	}
+	x.fails[fn] = false // break recursion
+	fails := false
+	if x.handler(decl.Body) == nil {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return x.handler(n.Body) == nil
+			case *ast.CallExpr:
+				name := x.checkFunc(n)
+				switch {
+				case strings.HasPrefix(name, "Catch"):
+					return false
+				case fix.Failing(name):
+					fails = true
+				default:
+					if callee := typeutil.StaticCallee(x.info, n); callee != nil && x.canFail(callee.Origin()) {
+						fails = true
+					}
+				}
+			}
+			return !fails
+		})
+	}
+	x.fails[fn] = fails
+	return fails
+}
+
+// container returns the statement that call is evaluated in, which must be
+// one that new statements can be inserted in front of without changing when
+// call is evaluated.
+func (f *function) container(call *ast.CallExpr) (ast.Stmt, string) {
+	path, _ := astutil.PathEnclosingInterval(f.file, call.Pos(), call.End())
+	for i, node := range path {
+		stmt, is := node.(ast.Stmt)
+		if !is {
+			continue
+		}
+		switch path[i+1].(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+		default:
+			return nil, "check call in a nested statement clause"
+		}
+		child := path[i-1]
+		switch stmt := stmt.(type) {
+		case *ast.ExprStmt, *ast.AssignStmt, *ast.ReturnStmt, *ast.DeclStmt,
+			*ast.SendStmt, *ast.IncDecStmt:
+		case *ast.IfStmt:
+			if stmt.Init != nil || child != stmt.Cond {
+				return nil, "check call in an if statement clause"
+			}
+		case *ast.SwitchStmt:
+			if stmt.Init != nil || child != stmt.Tag {
+				return nil, "check call in a switch statement clause"
+			}
+		case *ast.RangeStmt:
+			if child != stmt.X {
+				return nil, "check call in a for statement clause"
+			}
+		default:
+			return nil, "check call in an unsupported statement"
+		}
+		return stmt, ""
+	}
+	return nil, "check call outside a statement"
+}
+
+// statement replaces g.stmt with conventional code.
+func (f *function) statement(g *stmtSites) string {
+	// Fail and Failf are statements in their own right, and the statements
+	// after them become unreachable.
+	if s := g.sites[0]; s.arity() < 0 {
+		end, reason := f.unreachable(g.stmt)
+		if reason != "" {
+			return reason
+		}
+		text := strings.TrimSuffix(f.fail(s), "\n")
+		f.edits = append(f.edits, analysis.TextEdit{Pos: g.stmt.Pos(), End: end, NewText: []byte(text)})
+		return ""
+	}
+
+	// A statement consisting of a single MustN call becomes an assignment
+	// followed by the error check.
+	if len(g.sites) == 1 {
+		s := g.sites[0]
+		switch stmt := g.stmt.(type) {
+		case *ast.ExprStmt:
+			if stmt.X == s.call {
+				lhs := strings.Repeat("_, ", s.arity()) + f.errName
+				text := lhs + " = " + f.source(s.call.Args[0]) + "\n" + f.check()
+				f.edits = append(f.edits, analysis.TextEdit{Pos: stmt.Pos(), End: stmt.End(), NewText: []byte(text)})
+				return ""
+			}
+		case *ast.AssignStmt:
+			// Op-assignments such as += can't take the error too, so they
+			// are hoisted instead.
+			plain := stmt.Tok == token.ASSIGN || stmt.Tok == token.DEFINE
+			if plain && len(stmt.Rhs) == 1 && stmt.Rhs[0] == s.call && len(stmt.Lhs) == s.arity() {
+				text := f.source(span{stmt.Lhs[0].Pos(), stmt.Lhs[len(stmt.Lhs)-1].End()}) + ", " + f.errName + " " + stmt.Tok.String() + " " +
+					f.source(s.call.Args[0]) + "\n" + f.check()
+				f.edits = append(f.edits, analysis.TextEdit{Pos: stmt.Pos(), End: stmt.End(), NewText: []byte(text)})
+				return ""
+			}
+		}
+	}
+
+	// Otherwise each call is hoisted into temporaries ahead of the statement,
+	// which must not evaluate any other call before it.
+	for _, s := range g.sites {
+		if f.conditional(g.stmt, s.call) {
+			return "hoisting a check call would evaluate it unconditionally"
+		}
+		if f.callsBefore(g.stmt, s.call) {
+			return "hoisting a check call would reorder it after other calls"
+		}
+	}
+	var pre strings.Builder
+	stmtText := f.source(g.stmt)
+	base := f.fset.File(g.stmt.Pos()).Offset(g.stmt.Pos())
+	type repl struct {
+		start, end int
+		text       string
+	}
+	var repls []repl
+	for _, s := range g.sites {
+		if s.arity() < 0 {
+			return "check." + s.name + " used as an expression"
+		}
+		var temps []string
+		for i := 0; i < s.arity(); i++ {
+			temps = append(temps, f.fresh("v"))
+		}
+		lhs := strings.Join(append(append([]string{}, temps...), f.errName), ", ")
+		tok := ":="
+		if len(temps) == 0 {
+			tok = "="
+		}
+		fmt.Fprintf(&pre, "%s %s %s\n%s\n", lhs, tok, f.source(s.call.Args[0]), f.check())
+		start := f.fset.File(s.call.Pos()).Offset(s.call.Pos()) - base
+		end := f.fset.File(s.call.End()).Offset(s.call.End()) - base
+		repls = append(repls, repl{start, end, strings.Join(temps, ", ")})
+	}
+	sort.Slice(repls, func(i, j int) bool { return repls[i].start > repls[j].start })
+	for _, r := range repls {
+		if r.text == "" {
+			return "check.Must used as an expression"
+		}
+		stmtText = stmtText[:r.start] + r.text + stmtText[r.end:]
+	}
+	f.edits = append(f.edits, analysis.TextEdit{
+		Pos:     g.stmt.Pos(),
+		End:     g.stmt.End(),
+		NewText: []byte(pre.String() + stmtText),
+	})
+	return ""
+}
+
+// unreachable returns the end of the statements following stmt in its block,
+// which a return in its place makes unreachable, so that they can be removed
+// with it. Only returns and calls to panic, such as those that satisfy the
+// compiler after a call to Fail, can be removed, provided they don't refer to
+// local variables, which would be left unused.
+func (f *function) unreachable(stmt ast.Stmt) (token.Pos, string) {
+	path, _ := astutil.PathEnclosingInterval(f.file, stmt.Pos(), stmt.End())
+	for path[0] != stmt {
+		path = path[1:]
+	}
+	var list []ast.Stmt
+	switch block := path[1].(type) {
+	case *ast.BlockStmt:
+		list = block.List
+	case *ast.CaseClause:
+		list = block.Body
+	case *ast.CommClause:
+		list = block.Body
+	}
+	end := stmt.End()
+	following := false
+	for _, s := range list {
+		if s == stmt {
+			following = true
+			continue
+		}
+		if !following {
+			continue
+		}
+		switch s := s.(type) {
+		case *ast.ReturnStmt:
+		case *ast.ExprStmt:
+			call, is := s.X.(*ast.CallExpr)
+			if !is {
+				return 0, "statements after check.Fail would be unreachable"
+			}
+			if b, is := f.info.Uses[astIdent(call.Fun)].(*types.Builtin); !is || b.Name() != "panic" {
+				return 0, "statements after check.Fail would be unreachable"
+			}
+		default:
+			return 0, "statements after check.Fail would be unreachable"
+		}
+		if f.usesLocals(s) {
+			return 0, "statements after check.Fail would be unreachable"
+		}
+		end = s.End()
+	}
+	return end, ""
+}
+
+// usesLocals reports whether node refers to a variable declared in the
+// function's body.
+func (f *function) usesLocals(node ast.Node) bool {
+	body := f.decl.Body
+	uses := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if id, is := n.(*ast.Ident); is {
+			if v, is := f.info.Uses[id].(*types.Var); is && body.Pos() <= v.Pos() && v.Pos() < body.End() {
+				uses = true
+			}
+		}
+		return !uses
+	})
+	return uses
+}
+
+// nonNil reports whether the argument to the Fail call s is known not to be
+// nil: it is created by errors.New or fmt.Errorf, or it is a variable that an
+// enclosing if statement just tested against nil.
+func (f *function) nonNil(s site) bool {
+	arg := ast.Unparen(s.call.Args[0])
+	if call, is := arg.(*ast.CallExpr); is {
+		if fn, is := typeutil.Callee(f.info, call).(*types.Func); is && fn.Pkg() != nil {
+			name := fn.Pkg().Path() + "." + fn.Name()
+			return name == "errors.New" || name == "fmt.Errorf"
+		}
+		return false
+	}
+	id := astIdent(arg)
+	if id == nil {
+		return false
+	}
+	path, _ := astutil.PathEnclosingInterval(f.file, s.call.Pos(), s.call.End())
+	for i, node := range path {
+		ifs, is := node.(*ast.IfStmt)
+		if !is || path[i-1] != ifs.Body {
+			continue
+		}
+		cond, is := ast.Unparen(ifs.Cond).(*ast.BinaryExpr)
+		return is && cond.Op == token.NEQ &&
+			f.info.Uses[astIdent(cond.X)] == f.info.Uses[id] && f.info.Types[cond.Y].IsNil()
+	}
+	return false
+}
+
+// astIdent returns expr as an identifier, ignoring parentheses, or nil if it
+// isn't one.
+func astIdent(expr ast.Expr) *ast.Ident {
+	id, _ := ast.Unparen(expr).(*ast.Ident)
+	return id
+}
+
+// check returns the statement that tests errName after a hoisted call.
+func (f *function) check() string {
+	return "if " + f.errName + " != nil {\n" + f.failure(f.errName) + "}"
+}
+
+// fail returns the statements replacing a call to Fail or Failf.
+func (f *function) fail(s site) string {
+	if s.name == "Fail" {
+		if f.nonNil(s) {
+			return f.failure(f.source(s.call.Args[0]))
+		}
+		// Fail(nil) panics with check.ErrNilError, which Handle doesn't
+		// recover.
+		qual, edit := fix.Import(f.file, fix.CheckPath)
+		if edit != nil {
+			f.edits = append(f.edits, *edit)
+		}
+		return fmt.Sprintf("if %s = %s; %s == nil {\npanic(%sErrNilError)\n}\n%s",
+			f.errName, f.source(s.call.Args[0]), f.errName, qual, f.failure(f.errName))
+	}
+	qual, edit := fix.Import(f.file, "fmt")
+	if edit != nil {
+		f.edits = append(f.edits, *edit)
+	}
+	return f.failure(qual + "Errorf(" + f.source(span{s.call.Lparen + 1, s.call.Rparen}) + ")")
+}
+
+// failure returns the statements that return err from the function after
+// applying the transforms, mirroring check.Handle: a transform returning nil
+// makes the function return normally.
+func (f *function) failure(err string) string {
+	var sb strings.Builder
+	ret := func(err string) string {
+		return "return " + strings.Join(append(append([]string{}, f.results...), err), ", ") + "\n"
+	}
+	for _, t := range f.transforms {
+		if inlined, ok := f.inline(t, err); ok {
+			err = inlined
+			continue
+		}
+		fn, is := f.hoisted[t]
+		if !is {
+			fn = f.source(t)
+		}
+		fmt.Fprintf(&sb, "if %s = %s(%s); %s == nil {\n%s}\n",
+			f.errName, fn, err, f.errName, ret("nil"))
+		err = f.errName
+	}
+	sb.WriteString(ret(err))
+	return sb.String()
+}
+
+// inline returns the result of applying t to err as a single expression if t
+// is a function literal that just returns fmt.Errorf(…), which is never nil.
+func (f *function) inline(t ast.Expr, err string) (string, bool) {
+	lit, is := ast.Unparen(t).(*ast.FuncLit)
+	if !is || len(lit.Body.List) != 1 || len(lit.Type.Params.List) != 1 ||
+		len(lit.Type.Params.List[0].Names) != 1 {
+		return "", false
+	}
+	ret, is := lit.Body.List[0].(*ast.ReturnStmt)
+	if !is || len(ret.Results) != 1 {
+		return "", false
+	}
+	call, is := ret.Results[0].(*ast.CallExpr)
+	if !is {
+		return "", false
+	}
+	if fn, is := typeutil.Callee(f.info, call).(*types.Func); !is || fn.FullName() != "fmt.Errorf" {
+		return "", false
+	}
+	param := f.info.Defs[lit.Type.Params.List[0].Names[0]]
+	var uses []*ast.Ident
+	ast.Inspect(call, func(n ast.Node) bool {
+		if id, is := n.(*ast.Ident); is && f.info.Uses[id] == param {
+			uses = append(uses, id)
+		}
+		return true
+	})
+	if len(uses) > 1 && !token.IsIdentifier(err) {
+		return "", false
+	}
+	text := f.source(call)
+	base := f.fset.File(call.Pos()).Offset(call.Pos())
+	for i := len(uses) - 1; i >= 0; i-- {
+		start := f.fset.File(uses[i].Pos()).Offset(uses[i].Pos()) - base
+		text = text[:start] + err + text[start+len(uses[i].Name):]
+	}
+	return text, true
+}
+
+// conditional reports whether call is only evaluated by stmt depending on the
+// left operand of && or ||.
+func (f *function) conditional(stmt ast.Stmt, call *ast.CallExpr) bool {
+	path, _ := astutil.PathEnclosingInterval(f.file, call.Pos(), call.End())
+	for i, node := range path {
+		if node == ast.Node(stmt) {
+			break
+		}
+		if b, is := node.(*ast.BinaryExpr); is && i > 0 && path[i-1] == ast.Node(b.Y) &&
+			(b.Op == token.LAND || b.Op == token.LOR) {
+			return true
+		}
+	}
+	return false
+}
+
+// callsBefore reports whether evaluating stmt calls a function before call.
+func (f *function) callsBefore(stmt ast.Stmt, call *ast.CallExpr) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if n == ast.Node(call) {
+			return false
+		}
+		if c, is := n.(*ast.CallExpr); is && c.End() <= call.Pos() &&
+			!f.info.Types[c.Fun].IsType() && !f.isBuiltin(c) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+func (f *function) isBuiltin(call *ast.CallExpr) bool {
+	id, is := ast.Unparen(call.Fun).(*ast.Ident)
+	if !is {
+		return false
+	}
+	_, is = f.info.Uses[id].(*types.Builtin)
+	return is
+}
+
+// zero returns an expression for the zero value of the type expr.
+func (f *function) zero(expr ast.Expr) string {
+	t := f.info.TypeOf(expr)
+	if _, is := t.(*types.TypeParam); is {
+		return "*new(" + f.source(expr) + ")"
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Kind() == types.UnsafePointer:
+			return "nil"
+		}
+		return "0"
+	case *types.Struct, *types.Array:
+		return f.source(expr) + "{}"
+	}
+	return "nil"
+}
+
+// fresh returns an unused variable name based on name.
+func (f *function) fresh(name string) string {
+	candidate := name
+	for i := 1; f.used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	f.used[candidate] = true
+	return candidate
+}
+
+// checkFunc returns the name of the package check function called by call, or
+// "".
+func (x *expander) checkFunc(call *ast.CallExpr) string {
+	fn, is := typeutil.Callee(x.info, call).(*types.Func)
+	if !is || fn.Pkg() == nil || fn.Pkg().Path() != fix.CheckPath {
+		return ""
+	}
+	return fn.Name()
+}
+
+func (x *expander) source(node ast.Node) string {
+	tf := x.fset.File(node.Pos())
+	return string(x.src[tf.Offset(node.Pos()):tf.Offset(node.End())])
+}
+
+func (x *expander) skip(node ast.Node, reason string) {
+	x.skips = append(x.skips, cli.Skip{Pos: node.Pos(), Reason: reason})
+}
+
+// span is a range of source code.
+type span struct {
+	pos, end token.Pos
+}
+
+func (s span) Pos() token.Pos { return s.pos }
+func (s span) End() token.Pos { return s.end }
@@ -0,0 +1,530 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+// stubImporter imports package check from the analyzer test stub and
+// everything else from source.
+type stubImporter struct {
+	fset  *token.FileSet
+	check *types.Package
+	std   types.Importer
+}
+
+func (imp *stubImporter) Import(path string) (*types.Package, error) {
+	if path != fix.CheckPath {
+		return imp.std.Import(path)
+	}
+	if imp.check == nil {
+		name := filepath.Join("..", "..", "testdata", "src", fix.CheckPath, "check.go")
+		file, err := parser.ParseFile(imp.fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		conf := types.Config{Importer: imp.std}
+		if imp.check, err = conf.Check(path, imp.fset, []*ast.File{file}, nil); err != nil {
+			return nil, err
+		}
+	}
+	return imp.check, nil
+}
+
+// uncheck expands every function in src, returning the result and the
+// skipped reasons.
+func uncheck(t *testing.T, src string) (string, []string) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "a.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{Importer: &stubImporter{fset: fset, std: importer.ForCompiler(fset, "source", nil)}}
+	_, err = conf.Check("a", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	all := func(*ast.FuncDecl) bool { return true }
+	edits, skips := expand(fset, info, []*ast.File{file}, file, []byte(src), all)
+	var reasons []string
+	for _, s := range skips {
+		reasons = append(reasons, s.Reason)
+	}
+	if len(edits) == 0 {
+		return src, reasons
+	}
+	out, err := fix.Apply(fset.File(file.Pos()), []byte(src), edits)
+	require.NoError(t, err)
+	return string(out), reasons
+}
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+
+	out, skips := uncheck(t, `package a
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+type point struct{ x, y int }
+
+func Parse(name string) (_ int, p point, e error) {
+	defer check.Handle(&e)
+	check.Must(os.Remove(name))
+	b := check.Must1(os.ReadFile(name))
+	if len(b) == 0 {
+		check.Failf("empty %s", name)
+	}
+	p.x = check.Must1(strconv.Atoi(string(b)))
+	return check.Must1(strconv.Atoi(string(b))) * 2, p, nil
+}
+`)
+	assert.Empty(t, skips)
+	assert.Equal(t, `package a
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+type point struct{ x, y int }
+
+func Parse(name string) (_ int, p point, e error) {
+	e = os.Remove(name)
+	if e != nil {
+		return 0, p, e
+	}
+	b, e := os.ReadFile(name)
+	if e != nil {
+		return 0, p, e
+	}
+	if len(b) == 0 {
+		return 0, p, fmt.Errorf("empty %s", name)
+	}
+	p.x, e = strconv.Atoi(string(b))
+	if e != nil {
+		return 0, p, e
+	}
+	v, e := strconv.Atoi(string(b))
+	if e != nil {
+		return 0, p, e
+	}
+	return v * 2, p, nil
+}
+`, out)
+}
+
+func TestExpandTransforms(t *testing.T) {
+	t.Parallel()
+
+	out, skips := uncheck(t, `package a
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/goeezi/check"
+)
+
+var errSkip = errors.New("skip")
+
+func ignoreSkip(e error) error {
+	if errors.Is(e, errSkip) {
+		return nil
+	}
+	return e
+}
+
+func Read(name string) (_ []byte, e error) {
+	defer check.Handle(&e, ignoreSkip, func(e error) error {
+		return fmt.Errorf("reading %s: %w", name, e)
+	})
+	return check.Must1(os.ReadFile(name)), nil
+}
+`)
+	assert.Empty(t, skips)
+	assert.Equal(t, `package a
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+var errSkip = errors.New("skip")
+
+func ignoreSkip(e error) error {
+	if errors.Is(e, errSkip) {
+		return nil
+	}
+	return e
+}
+
+func Read(name string) (_ []byte, e error) {
+	v, e := os.ReadFile(name)
+	if e != nil {
+		if e = ignoreSkip(e); e == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", name, e)
+	}
+	return v, nil
+}
+`, out)
+}
+
+func TestExpandSkips(t *testing.T) {
+	t.Parallel()
+
+	src := `package a
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+func parse(s string) int {
+	return check.Must1(strconv.Atoi(s))
+}
+
+func Indirect(s string) (_ int, e error) {
+	defer check.Handle(&e)
+	return parse(s), nil
+}
+
+func Closure(name string) (e error) {
+	defer check.Handle(&e)
+	func() {
+		check.Must(os.Remove(name))
+	}()
+	return nil
+}
+
+func Wrapped(name string) (e error) {
+	defer check.Wrap(&e, 0)
+	check.Must(os.Remove(name))
+	return nil
+}
+
+func All(name string) (e error) {
+	defer check.HandleAll(&e)
+	check.Must(os.Remove(name))
+	return nil
+}
+
+func Typed(v any) (_ string, e error) {
+	defer check.Handle(&e)
+	return check.Cast[string](v), nil
+}
+
+func Reordered(a, b string) (_ int, e error) {
+	defer check.Handle(&e)
+	return len(os.Args) + parse(a) + check.Must1(strconv.Atoi(b)), nil
+}
+
+func Loop(name string) (e error) {
+	defer check.Handle(&e)
+	for check.Must1(os.Stat(name)).Size() > 0 {
+	}
+	return nil
+}
+`
+	out, skips := uncheck(t, src)
+	assert.Equal(t, src, out)
+	assert.Equal(t, []string{
+		"function doesn't defer check.Handle",
+		"calls parse, which can fail via package check",
+		"check call inside a function literal",
+		"check.Wrap records a stack trace, which conventional code doesn't",
+		"check.HandleAll recovers other panics, which conventional code doesn't",
+		"check.Cast isn't supported",
+		"calls parse, which can fail via package check",
+		"check call in an unsupported statement",
+	}, skips)
+}
+
+func TestExpandOpAssign(t *testing.T) {
+	t.Parallel()
+
+	out, skips := uncheck(t, `package a
+
+import (
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+func Sum(ss []string) (n int, e error) {
+	defer check.Handle(&e)
+	for _, s := range ss {
+		n += check.Must1(strconv.Atoi(s))
+	}
+	return n, nil
+}
+`)
+	assert.Empty(t, skips)
+	assert.Equal(t, `package a
+
+import (
+	"strconv"
+)
+
+func Sum(ss []string) (n int, e error) {
+	for _, s := range ss {
+		v, e := strconv.Atoi(s)
+		if e != nil {
+			return n, e
+		}
+		n += v
+	}
+	return n, nil
+}
+`, out)
+}
+
+func TestExpandHoistedTransforms(t *testing.T) {
+	t.Parallel()
+
+	out, skips := uncheck(t, `package a
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goeezi/check"
+)
+
+func prefix(msg string) func(error) error {
+	return func(e error) error { return fmt.Errorf("%s: %w", msg, e) }
+}
+
+func Remove(a, b string) (e error) {
+	defer check.Handle(&e, prefix("removing"))
+	check.Must(os.Remove(a))
+	check.Must(os.Remove(b))
+	return nil
+}
+`)
+	assert.Empty(t, skips)
+	assert.Equal(t, `package a
+
+import (
+	"fmt"
+	"os"
+)
+
+func prefix(msg string) func(error) error {
+	return func(e error) error { return fmt.Errorf("%s: %w", msg, e) }
+}
+
+func Remove(a, b string) (e error) {
+	transform := prefix("removing")
+	e = os.Remove(a)
+	if e != nil {
+		if e = transform(e); e == nil {
+			return nil
+		}
+		return e
+	}
+	e = os.Remove(b)
+	if e != nil {
+		if e = transform(e); e == nil {
+			return nil
+		}
+		return e
+	}
+	return nil
+}
+`, out)
+}
+
+func TestExpandConditional(t *testing.T) {
+	t.Parallel()
+
+	src := `package a
+
+import (
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+type loader interface{ Load() (bool, error) }
+
+func And(ok bool, s string) (_ bool, e error) {
+	defer check.Handle(&e)
+	return ok && check.Must1(strconv.ParseBool(s)), nil
+}
+
+func Guarded(p loader) (e error) {
+	defer check.Handle(&e)
+	if p != nil && check.Must1(p.Load()) {
+		return nil
+	}
+	return nil
+}
+
+func Or(ok bool, s string) (_ bool, e error) {
+	defer check.Handle(&e)
+	return ok || (check.Must1(strconv.ParseBool(s))), nil
+}
+
+func Early(s string) (_ int, e error) {
+	n := check.Must1(strconv.Atoi(s))
+	defer check.Handle(&e)
+	return n, nil
+}
+`
+	out, skips := uncheck(t, src)
+	assert.Equal(t, src, out)
+	assert.Equal(t, []string{
+		"hoisting a check call would evaluate it unconditionally",
+		"hoisting a check call would evaluate it unconditionally",
+		"hoisting a check call would evaluate it unconditionally",
+		"check call before the deferred check.Handle",
+	}, skips)
+}
+
+func TestExpandFail(t *testing.T) {
+	t.Parallel()
+
+	out, skips := uncheck(t, `package a
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+var errEmpty = errors.New("empty")
+
+func Validate(s string) (e error) {
+	defer check.Handle(&e)
+	if s == "" {
+		check.Fail(errEmpty)
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		check.Fail(err)
+	}
+	if n < 0 {
+		check.Fail(fmt.Errorf("negative: %d", n))
+		return nil
+	}
+	return nil
+}
+`)
+	assert.Empty(t, skips)
+	assert.Equal(t, `package a
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+var errEmpty = errors.New("empty")
+
+func Validate(s string) (e error) {
+	if s == "" {
+		if e = errEmpty; e == nil {
+			panic(check.ErrNilError)
+		}
+		return e
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return fmt.Errorf("negative: %d", n)
+	}
+	return nil
+}
+`, out)
+}
+
+func TestExpandUnsafe(t *testing.T) {
+	t.Parallel()
+
+	src := `package a
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goeezi/check"
+)
+
+var errEmpty = errors.New("empty")
+
+type tx struct{}
+
+func (tx) Commit() error   { return nil }
+func (tx) Rollback() error { return nil }
+
+func Dead(s string) (e error) {
+	defer check.Handle(&e)
+	n := len(s)
+	check.Fail(errEmpty)
+	fmt.Println(n)
+	return nil
+}
+
+func RollsBack(t tx) (e error) {
+	defer check.Handle(&e)
+	defer func() {
+		if e != nil {
+			t.Rollback()
+		}
+	}()
+	check.Must(t.Commit())
+	return nil
+}
+
+func Recovers(t tx) (e error) {
+	defer check.Handle(&e)
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println(r)
+			panic(r)
+		}
+	}()
+	check.Must(t.Commit())
+	return nil
+}
+`
+	out, skips := uncheck(t, src)
+	assert.Equal(t, src, out)
+	assert.Equal(t, []string{
+		"statements after check.Fail would be unreachable",
+		"function defers a call that uses the error result",
+		"function defers a call that recovers panics",
+	}, skips)
+}
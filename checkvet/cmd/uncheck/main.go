@@ -0,0 +1,85 @@
+// Command uncheck expands code that uses package check back into conventional
+// error handling, for functions on hot paths where the cost of panic and
+// recover matters.
+//
+// In each function that defers check.Handle(&e, transforms...), it replaces
+//
+//	x := check.Must1(f())
+//
+// with
+//
+//	x, e := f()
+//	if e != nil {
+//		return 0, e
+//	}
+//
+// applying the transforms inline, and likewise expands Must, MustN, Fail and
+// Failf before removing the deferred Handle. Failure paths return the current
+// values of named results and zero values for the rest, exactly as Handle
+// would. Functions that can't be expanded without changing behaviour, such as
+// those that call check functions inside closures, are left untouched and
+// reported on standard error.
+//
+// Usage:
+//
+//	uncheck [-func names] [-l] [-w] [packages]
+//
+// By default, uncheck prints the rewritten files to standard output.
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/goeezi/check/checkvet/internal/cli"
+)
+
+var funcs = flag.String("func", "",
+	"comma-separated functions to expand, as Name or Type.Method (default all)")
+
+func main() {
+	cli.Main("uncheck", func(pkg *packages.Package, file *ast.File, src []byte) ([]analysis.TextEdit, []cli.Skip) {
+		return expand(pkg.Fset, pkg.TypesInfo, pkg.Syntax, file, src, selected)
+	})
+}
+
+// selected reports whether decl was selected by the -func flag.
+func selected(decl *ast.FuncDecl) bool {
+	if *funcs == "" {
+		return true
+	}
+	name := decl.Name.Name
+	if decl.Recv != nil && len(decl.Recv.List) > 0 {
+		name = recvName(decl.Recv.List[0].Type) + "." + name
+	}
+	for _, want := range strings.Split(*funcs, ",") {
+		if strings.TrimSpace(want) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// recvName returns the base type name of a receiver type expression.
+func recvName(expr ast.Expr) string {
+	for {
+		switch x := expr.(type) {
+		case *ast.StarExpr:
+			expr = x.X
+		case *ast.ParenExpr:
+			expr = x.X
+		case *ast.IndexExpr:
+			expr = x.X
+		case *ast.IndexListExpr:
+			expr = x.X
+		case *ast.Ident:
+			return x.Name
+		default:
+			return ""
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package checkvet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+// Analyzer reports check failures that can escape an exported API or crash
+// the process from a goroutine.
+var Analyzer = &analysis.Analyzer{
+	Name: "checkescape",
+	Doc: `report check failures that can escape a package or a goroutine
+
+Functions that call check.Must…, check.Fail or check.Failf panic with a
+check.Error on failure. Exported functions and methods must recover such panics
+with a deferred check.Handle or check.Wrap, or by running the work inside
+check.Catch…. The same applies to the body of a go statement, since an
+unrecovered panic in a goroutine terminates the program.`,
+	Run: runEscape,
+}
+
+// unit is a body of code that either recovers check failures or lets them
+// escape: a function declaration or the function run by a go statement.
+type unit struct {
+	name    string
+	file    *ast.File
+	decl    *ast.FuncDecl
+	goStmt  *ast.GoStmt
+	api     bool
+	handled bool
+	sites   []*site
+	leak    *site // first site found to let a failure escape
+}
+
+// site is a call that can fail, either a direct call to a failing check
+// function or a call to a same-package function that can let one escape.
+type site struct {
+	call   *ast.CallExpr
+	check  string
+	callee *unit
+}
+
+// String describes the chain of calls from s to the failing check function.
+func (s *site) String() string {
+	var sb strings.Builder
+	for ; s.callee != nil; s = s.callee.leak {
+		sb.WriteString(s.callee.name)
+		sb.WriteString(" → ")
+	}
+	sb.WriteString("check.")
+	sb.WriteString(s.check)
+	return sb.String()
+}
+
+// origin returns the call that raises the failure at the end of the chain.
+func (s *site) origin() *ast.CallExpr {
+	for s.callee != nil {
+		s = s.callee.leak
+	}
+	return s.call
+}
+
+type escape struct {
+	pass  *analysis.Pass
+	funcs map[*types.Func]*unit
+	units []*unit
+}
+
+func runEscape(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Path() == checkPath {
+		return nil, nil
+	}
+	e := &escape{
+		pass:  pass,
+		funcs: map[*types.Func]*unit{},
+	}
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, is := decl.(*ast.FuncDecl); is && decl.Body != nil {
+				u := &unit{
+					name:    funcName(decl),
+					file:    file,
+					decl:    decl,
+					api:     isAPI(decl),
+					handled: deferredHandler(pass.TypesInfo, decl.Body) != nil,
+				}
+				if fn, is := pass.TypesInfo.Defs[decl.Name].(*types.Func); is {
+					e.funcs[fn] = u
+				}
+				e.units = append(e.units, u)
+			}
+		}
+	}
+	// Scanning appends goroutine units, but range only sees the declarations.
+	for _, u := range e.units {
+		e.scan(u, u.decl.Body)
+	}
+	e.solve()
+
+	for _, u := range e.units {
+		switch {
+		case u.leak == nil:
+		case u.goStmt != nil:
+			pass.Report(analysis.Diagnostic{
+				Pos: u.goStmt.Pos(),
+				Message: fmt.Sprintf(
+					"goroutine can crash the process via %s; recover with check.Catch or a deferred check.Handle, or use check.Group",
+					u.leak),
+				Related: related(u.leak),
+			})
+		case u.api && !isTestFile(pass, u.decl):
+			d := analysis.Diagnostic{
+				Pos: u.decl.Name.Pos(),
+				Message: fmt.Sprintf(
+					"%s can leak a check failure via %s; defer check.Handle or check.Wrap, or use check.Catch",
+					u.name, u.leak),
+				Related: related(u.leak),
+			}
+			if fix := handleFix(pass, u.file, u.decl); fix != nil {
+				d.SuggestedFixes = []analysis.SuggestedFix{*fix}
+			}
+			pass.Report(d)
+		}
+	}
+	return nil, nil
+}
+
+// scan records in u the failing calls in node that aren't recovered by a
+// nested check.Catch… or a deferred handler in a nested function literal.
+// Sites are recorded even if u is handled, since scanning also finds the go
+// statements nested in node.
+func (e *escape) scan(u *unit, node ast.Node) {
+	info := e.pass.TypesInfo
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			if deferredHandler(info, n.Body) != nil {
+				e.scan(&unit{handled: true}, n.Body)
+				return false
+			}
+		case *ast.GoStmt:
+			e.goStmt(u, n)
+			return false
+		case *ast.CallExpr:
+			name := checkFunc(info, n)
+			switch {
+			case isCatching(name):
+				for i, arg := range n.Args {
+					if fun, is := arg.(*ast.FuncLit); i == workArg(name) && is {
+						e.scan(&unit{handled: true}, fun.Body)
+					} else {
+						e.scan(u, arg)
+					}
+				}
+				return false
+			case fix.Failing(name):
+				u.sites = append(u.sites, &site{call: n, check: name})
+			default:
+				if v := e.callee(n); v != nil && v != u && !v.api {
+					u.sites = append(u.sites, &site{call: n, callee: v})
+				}
+			}
+		}
+		return true
+	})
+}
+
+// goStmt creates a unit for the function run by g. Arguments to the call are
+// evaluated by the calling goroutine, so they are scanned as part of parent.
+func (e *escape) goStmt(parent *unit, g *ast.GoStmt) {
+	u := &unit{name: "goroutine", goStmt: g}
+	e.units = append(e.units, u)
+	switch fun := g.Call.Fun.(type) {
+	case *ast.FuncLit:
+		u.handled = deferredHandler(e.pass.TypesInfo, fun.Body) != nil
+		e.scan(u, fun.Body)
+	default:
+		if name := checkFunc(e.pass.TypesInfo, g.Call); fix.Failing(name) {
+			u.sites = append(u.sites, &site{call: g.Call, check: name})
+		} else if v := e.callee(g.Call); v != nil {
+			u.sites = append(u.sites, &site{call: g.Call, callee: v})
+		}
+	}
+	for _, arg := range g.Call.Args {
+		e.scan(parent, arg)
+	}
+}
+
+// callee returns the unit for the same-package function statically called by
+// call, if any.
+func (e *escape) callee(call *ast.CallExpr) *unit {
+	if fn := typeutil.StaticCallee(e.pass.TypesInfo, call); fn != nil {
+		return e.funcs[fn.Origin()]
+	}
+	return nil
+}
+
+// solve propagates failures from callees to callers until nothing changes.
+func (e *escape) solve() {
+	for changed := true; changed; {
+		changed = false
+		for _, u := range e.units {
+			if u.handled || u.leak != nil {
+				continue
+			}
+			for _, s := range u.sites {
+				if s.callee == nil || s.callee.leak != nil {
+					u.leak = s
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+func related(s *site) []analysis.RelatedInformation {
+	return []analysis.RelatedInformation{{
+		Pos:     s.origin().Pos(),
+		Message: "failure raised here",
+	}}
+}
+
+func isTestFile(pass *analysis.Pass, node ast.Node) bool {
+	return strings.HasSuffix(pass.Fset.File(node.Pos()).Name(), "_test.go")
+}
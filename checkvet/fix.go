@@ -0,0 +1,21 @@
+package checkvet
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+// handleFix returns a fix that makes decl recover check failures by naming
+// its results and deferring check.Handle. It returns nil if decl doesn't
+// return an error, since adding one would change its signature for every
+// caller.
+func handleFix(pass *analysis.Pass, file *ast.File, decl *ast.FuncDecl) *analysis.SuggestedFix {
+	edits, ok := fix.Handle(pass.Fset, pass.TypesInfo, file, decl.Type, decl.Body)
+	if !ok {
+		return nil
+	}
+	return &analysis.SuggestedFix{Message: "Defer check.Handle", TextEdits: edits}
+}
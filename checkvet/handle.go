@@ -0,0 +1,132 @@
+package checkvet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// HandleAnalyzer reports calls to check.Handle and check.Wrap that can't work
+// as intended.
+var HandleAnalyzer = &analysis.Analyzer{
+	Name: "checkhandle",
+	Doc: `report misused check.Handle and check.Wrap calls
+
+check.Handle and check.Wrap call recover, so they only work when deferred
+directly. They assign the recovered error through their first argument, which
+must point at a named error result of the enclosing function for the error to
+reach the caller. Passing nil re-panics the failure, which is rarely what a
+function that returns an error wants.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runHandle,
+}
+
+func runHandle(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{(*ast.CallExpr)(nil)}
+	insp.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call := n.(*ast.CallExpr)
+		name := checkFunc(pass.TypesInfo, call)
+		if !push || !isHandling(name) {
+			return true
+		}
+		if d, is := stack[len(stack)-2].(*ast.DeferStmt); !is || d.Call != call {
+			if enclosingDefer(stack) {
+				pass.Reportf(call.Pos(),
+					"check.%s must be deferred directly; recover has no effect in a nested call", name)
+			} else {
+				pass.Reportf(call.Pos(),
+					"check.%s must be deferred; called directly, it can't recover a failure", name)
+			}
+			return true
+		}
+		if len(call.Args) == 0 {
+			return true
+		}
+		results := enclosingResults(stack)
+		switch arg := ast.Unparen(call.Args[0]).(type) {
+		case *ast.Ident:
+			if pass.TypesInfo.Types[arg].IsNil() {
+				if returnsError(pass.TypesInfo, results) {
+					pass.Reportf(arg.Pos(),
+						"check.%s(nil) re-panics failures in a function that returns an error; pass a pointer to the named error result", name)
+				}
+				return true
+			}
+		case *ast.UnaryExpr:
+			if id, is := ast.Unparen(arg.X).(*ast.Ident); is {
+				if isResult(pass.TypesInfo, results, pass.TypesInfo.Uses[id]) {
+					return true
+				}
+			}
+		}
+		pass.Reportf(call.Args[0].Pos(),
+			"check.%s should be given a pointer to a named error result of the enclosing function; otherwise the error is lost", name)
+		return true
+	})
+	return nil, nil
+}
+
+// enclosingDefer reports whether the innermost function in stack is a
+// function literal that is deferred.
+func enclosingDefer(stack []ast.Node) bool {
+	for i := len(stack) - 1; i > 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			return false
+		case *ast.FuncLit:
+			call, is := stack[i-1].(*ast.CallExpr)
+			if !is || call.Fun != n || i < 2 {
+				return false
+			}
+			_, is = stack[i-2].(*ast.DeferStmt)
+			return is
+		}
+	}
+	return false
+}
+
+// enclosingResults returns the results of the innermost function in stack.
+func enclosingResults(stack []ast.Node) *ast.FieldList {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			return n.Type.Results
+		case *ast.FuncLit:
+			return n.Type.Results
+		}
+	}
+	return nil
+}
+
+// isResult reports whether obj is one of the named results.
+func isResult(info *types.Info, results *ast.FieldList, obj types.Object) bool {
+	if results == nil || obj == nil {
+		return false
+	}
+	for _, field := range results.List {
+		for _, name := range field.Names {
+			if info.Defs[name] == obj {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// returnsError reports whether any of the results has type error.
+func returnsError(info *types.Info, results *ast.FieldList) bool {
+	if results == nil {
+		return false
+	}
+	errorType := types.Universe.Lookup("error").Type()
+	for _, field := range results.List {
+		if types.Identical(info.TypeOf(field.Type), errorType) {
+			return true
+		}
+	}
+	return false
+}
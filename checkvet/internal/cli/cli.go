@@ -0,0 +1,95 @@
+// Package cli implements the command-line driver shared by the rewriting
+// commands.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/goeezi/check/checkvet/internal/fix"
+)
+
+// Skip records code that a rewriter left untouched and why.
+type Skip struct {
+	Pos    token.Pos
+	Reason string
+}
+
+// Rewriter returns the edits to apply to file, whose content is src, and the
+// code it skipped.
+type Rewriter func(pkg *packages.Package, file *ast.File, src []byte) ([]analysis.TextEdit, []Skip)
+
+// Main parses the command line, loads the packages it names and rewrites
+// every file in them. By default, rewritten files are printed to standard
+// output. Skipped code is reported on standard error. Commands may define
+// flags of their own before calling Main.
+func Main(name string, rewrite Rewriter) {
+	list := flag.Bool("l", false, "list files that would be rewritten")
+	write := flag.Bool("w", false, "write the result to the source files")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] [packages]\n", name)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if err := run(flag.Args(), rewrite, *list, *write); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+func run(patterns []string, rewrite Rewriter, list, write bool) error {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("packages contain errors")
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			tf := pkg.Fset.File(file.Pos())
+			src, err := os.ReadFile(tf.Name())
+			if err != nil {
+				return err
+			}
+			edits, skips := rewrite(pkg, file, src)
+			sort.Slice(skips, func(i, j int) bool { return skips[i].Pos < skips[j].Pos })
+			for _, s := range skips {
+				fmt.Fprintf(os.Stderr, "%s: skipped: %s\n", pkg.Fset.Position(s.Pos), s.Reason)
+			}
+			if len(edits) == 0 {
+				continue
+			}
+			out, err := fix.Apply(tf, src, edits)
+			if err != nil {
+				return err
+			}
+			if list {
+				fmt.Println(tf.Name())
+			}
+			if write {
+				if err := os.WriteFile(tf.Name(), out, 0o644); err != nil {
+					return err
+				}
+			}
+			if !list && !write {
+				os.Stdout.Write(out)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+// Package fix computes source edits shared by the checkvet analyzers and the
+// rewriting commands.
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	pathpkg "path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// CheckPath is the import path of package check.
+const CheckPath = "github.com/goeezi/check"
+
+// Failing reports whether the named function in package check raises
+// failures by panicking with check.Error. Values fails when its result is
+// ranged over, which is almost always where it is called. Close and CloseFunc
+// raise the close error as a failure when given a nil error pointer.
+func Failing(name string) bool {
+	switch name {
+	case "Cast", "Key", "Index", "Values", "Close", "CloseFunc":
+		return true
+	}
+	return strings.HasPrefix(name, "Must") || strings.HasPrefix(name, "Fail")
+}
+
+// MaxArity returns the highest N for which the package check imported by pkg
+// provides MustN. If pkg doesn't import package check, it returns the highest
+// N of the package check that this module was generated alongside.
+func MaxArity(pkg *types.Package) int {
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == CheckPath {
+			n := 0
+			for imp.Scope().Lookup("Must"+strconv.Itoa(n+1)) != nil {
+				n++
+			}
+			return n
+		}
+	}
+	return maxArity
+}
+
+// DeferConflict returns why the calls deferred in body other than handler
+// prevent converting between error checks and check failures, or "" if none
+// do. Such calls run before a check.Handle deferred ahead of them, so they
+// would see the error result errObj before Handle sets it, and would recover
+// the failures themselves if they call recover.
+func DeferConflict(info *types.Info, body *ast.BlockStmt, handler *ast.DeferStmt, errObj types.Object) string {
+	why := ""
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Deferred calls in function literals belong to them.
+			return false
+		case *ast.DeferStmt:
+			if n != handler {
+				why = deferConflict(info, n, errObj)
+			}
+			return false
+		}
+		return why == ""
+	})
+	return why
+}
+
+func deferConflict(info *types.Info, d *ast.DeferStmt, errObj types.Object) string {
+	why := ""
+	ast.Inspect(d.Call, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if errObj != nil && info.Uses[n] == errObj {
+				why = "function defers a call that uses the error result"
+			}
+		case *ast.CallExpr:
+			id, _ := ast.Unparen(n.Fun).(*ast.Ident)
+			if b, is := info.Uses[id].(*types.Builtin); is && b.Name() == "recover" {
+				why = "function defers a call that recovers panics"
+			}
+		}
+		return why == ""
+	})
+	return why
+}
+
+// Handle returns edits that make the function with type ftype and body
+// recover check failures by naming its results and deferring check.Handle
+// with the given transforms, which are Go expressions. It reports false if
+// the function doesn't return an error or its body is empty.
+func Handle(
+	fset *token.FileSet,
+	info *types.Info,
+	file *ast.File,
+	ftype *ast.FuncType,
+	body *ast.BlockStmt,
+	transforms ...string,
+) (edits []analysis.TextEdit, ok bool) {
+	if len(body.List) == 0 || ftype.Results == nil {
+		return nil, false
+	}
+	last := ftype.Results.List[len(ftype.Results.List)-1]
+	if !IsError(info.TypeOf(last.Type)) {
+		return nil, false
+	}
+
+	errName := ""
+	if len(last.Names) > 0 {
+		errName = last.Names[len(last.Names)-1].Name
+	}
+	if errName == "" || errName == "_" {
+		// Rewrite the result list to name the error.
+		errName = FreeName([]string{"e", "err"}, ftype, body)
+		var buf bytes.Buffer
+		buf.WriteString("(")
+		for i, field := range ftype.Results.List {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			names := make([]string, len(field.Names))
+			for j, name := range field.Names {
+				names[j] = name.Name
+			}
+			switch {
+			case i == len(ftype.Results.List)-1:
+				if len(names) == 0 {
+					names = []string{errName}
+				} else {
+					names[len(names)-1] = errName
+				}
+			case len(names) == 0:
+				names = []string{"_"}
+			}
+			buf.WriteString(strings.Join(names, ", "))
+			buf.WriteString(" ")
+			buf.WriteString(exprString(fset, field.Type))
+		}
+		buf.WriteString(")")
+		rs := ftype.Results
+		edits = append(edits, analysis.TextEdit{Pos: rs.Pos(), End: rs.End(), NewText: buf.Bytes()})
+	}
+
+	qual, edit := Import(file, CheckPath)
+	if edit != nil {
+		edits = append(edits, *edit)
+	}
+	args := append([]string{"&" + errName}, transforms...)
+	first := body.List[0].Pos()
+	edits = append(edits, analysis.TextEdit{
+		Pos:     first,
+		End:     first,
+		NewText: []byte(fmt.Sprintf("defer %sHandle(%s)\n\t", qual, strings.Join(args, ", "))),
+	})
+	return edits, true
+}
+
+// IsError reports whether t is the predeclared error type.
+func IsError(t types.Type) bool {
+	return t != nil && types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// FreeName returns the first of candidates, or a numbered variant of the
+// first, that isn't an identifier in any of nodes.
+func FreeName(candidates []string, nodes ...ast.Node) string {
+	used := map[string]bool{}
+	for _, node := range nodes {
+		ast.Inspect(node, func(n ast.Node) bool {
+			if id, is := n.(*ast.Ident); is {
+				used[id.Name] = true
+			}
+			return true
+		})
+	}
+	for _, name := range candidates {
+		if !used[name] {
+			return name
+		}
+	}
+	for i := 1; ; i++ {
+		if name := candidates[0] + strconv.Itoa(i); !used[name] {
+			return name
+		}
+	}
+}
+
+// Import returns the qualifier, including the trailing dot, by which file
+// refers to the package with the given import path. If file doesn't import
+// it, it also returns an edit that adds the import.
+func Import(file *ast.File, path string) (string, *analysis.TextEdit) {
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+			switch {
+			case spec.Name == nil:
+				return pathpkg.Base(path) + ".", nil
+			case spec.Name.Name == ".":
+				return "", nil
+			default:
+				return spec.Name.Name + ".", nil
+			}
+		}
+	}
+	qual := pathpkg.Base(path) + "."
+	for _, decl := range file.Decls {
+		if gen, is := decl.(*ast.GenDecl); is && gen.Tok == token.IMPORT && gen.Lparen.IsValid() {
+			pos := gen.Rparen
+			text := "\n\t" + strconv.Quote(path) + "\n"
+			if !strings.Contains(path, ".") {
+				// Standard library imports go in the first group.
+				pos = gen.Lparen + 1
+				text = "\n\t" + strconv.Quote(path)
+			}
+			return qual, &analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(text)}
+		}
+	}
+	pos := file.Name.End()
+	return qual, &analysis.TextEdit{Pos: pos, End: pos, NewText: []byte("\n\nimport " + strconv.Quote(path))}
+}
+
+// Apply applies edits to src, the content of file, and formats the result,
+// dropping the import of package check if the edits leave it unused.
+// Identical edits are applied once. Insertions at the same position as a
+// replacement precede it.
+func Apply(file *token.File, src []byte, edits []analysis.TextEdit) ([]byte, error) {
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Pos != edits[j].Pos {
+			return edits[i].Pos < edits[j].Pos
+		}
+		return edits[i].End < edits[j].End
+	})
+	var buf bytes.Buffer
+	offset := 0
+	for i, edit := range edits {
+		if i > 0 && edit.Pos == edits[i-1].Pos && edit.End == edits[i-1].End &&
+			bytes.Equal(edit.NewText, edits[i-1].NewText) {
+			continue
+		}
+		start, end := file.Offset(edit.Pos), file.Offset(edit.End)
+		if start < offset {
+			return nil, fmt.Errorf("%s: overlapping edits", file.Position(edit.Pos))
+		}
+		buf.Write(src[offset:start])
+		buf.Write(edit.NewText)
+		offset = end
+	}
+	buf.Write(src[offset:])
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file.Name(), buf.Bytes(), parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if !astutil.UsesImport(f, CheckPath) {
+		astutil.DeleteImport(fset, f, CheckPath)
+	}
+	buf.Reset()
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, expr); err != nil {
+		return types.ExprString(expr)
+	}
+	return buf.String()
+}
@@ -0,0 +1,25 @@
+package checkvet
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+)
+
+func init() {
+	register.Plugin("checkvet", newPlugin)
+}
+
+// plugin adapts Analyzers to golangci-lint's module plugin system.
+type plugin struct{}
+
+func newPlugin(any) (register.LinterPlugin, error) {
+	return plugin{}, nil
+}
+
+func (plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return Analyzers, nil
+}
+
+func (plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
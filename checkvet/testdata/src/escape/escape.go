@@ -0,0 +1,268 @@
+package escape
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/goeezi/check"
+	"github.com/goeezi/check/checkhttp"
+	"github.com/goeezi/check/checktest"
+)
+
+var errOops = errors.New("oops")
+
+func Direct(s string) int { // want `Direct can leak a check failure via check.Must1`
+	return check.Must1(strconv.Atoi(s))
+}
+
+func Handled(s string) (_ int, e error) {
+	defer check.Handle(&e)
+	return check.Must1(strconv.Atoi(s)), nil
+}
+
+func Wrapped(s string) (_ int, e error) {
+	defer check.Wrap(&e, 0)
+	return check.Must1(strconv.Atoi(s)), nil
+}
+
+func Caught(s string) (int, error) {
+	return check.Catch1(func() int {
+		return check.Must1(strconv.Atoi(s))
+	})
+}
+
+func parse(s string) int {
+	return check.Must1(strconv.Atoi(s))
+}
+
+func double(s string) int {
+	return 2 * parse(s)
+}
+
+func Indirect(s string) int { // want `Indirect can leak a check failure via double → parse → check.Must1`
+	return double(s)
+}
+
+func IndirectHandled(s string) (_ int, e error) {
+	defer check.Handle(&e)
+	return double(s), nil
+}
+
+func Failer() { // want `Failer can leak a check failure via check.Failf`
+	check.Failf("no %s", "way")
+}
+
+func Closer(f *os.File) { // want `Closer can leak a check failure via check.Close`
+	defer check.Close(nil, f)
+}
+
+func CloserFunc(f *os.File) { // want `CloserFunc can leak a check failure via check.CloseFunc`
+	defer check.CloseFunc(nil, f.Close)
+}
+
+func Closed(name string) (e error) {
+	defer check.Handle(&e)
+	f := check.Must1(os.Open(name))
+	defer check.Close(&e, f)
+	return nil
+}
+
+func Closure() { // want `Closure can leak a check failure via check.Fail`
+	func() {
+		check.Fail(errOops)
+	}()
+}
+
+func ClosureHandled() {
+	func() (e error) {
+		defer check.Handle(&e)
+		check.Fail(errOops)
+		return nil
+	}()
+}
+
+func unexported() {
+	check.Must(errOops)
+}
+
+type T struct{}
+
+func (T) Method() { // want `T.Method can leak a check failure via check.Must`
+	check.Must(errOops)
+}
+
+func (*T) Pointer() { // want `T.Pointer can leak a check failure via unexported → check.Must`
+	unexported()
+}
+
+type t struct{}
+
+func (t) Method() {
+	check.Must(errOops)
+}
+
+func Exported() { // want `Exported can leak a check failure via check.Must`
+	check.Must(errOops)
+}
+
+func CallsExported() {
+	// Exported reports its own leak.
+	Exported()
+}
+
+func recursive(n int) int {
+	if n == 0 {
+		check.Must(errOops)
+	}
+	return recursive(n - 1)
+}
+
+func Recursive() { // want `Recursive can leak a check failure via recursive → check.Must`
+	recursive(3)
+}
+
+func Goroutine() {
+	go func() { // want `goroutine can crash the process via check.Must`
+		check.Must(errOops)
+	}()
+}
+
+func GoroutineIndirect() {
+	go unexported() // want `goroutine can crash the process via unexported → check.Must`
+}
+
+func GoroutineCaught() {
+	go func() {
+		_ = check.Catch(func() {
+			check.Must(errOops)
+		})
+	}()
+}
+
+func GoroutineHandled() {
+	go func() {
+		var err error
+		defer check.Handle(&err)
+		check.Must(errOops)
+	}()
+}
+
+func GoroutineArgs(s string) (e error) {
+	defer check.Handle(&e)
+	go func(int) {}(parse(s))
+	return nil
+}
+
+func NestedGoroutine() (e error) {
+	defer check.Handle(&e)
+	go func() { // want `goroutine can crash the process via parse → check.Must1`
+		parse("x")
+	}()
+	return nil
+}
+
+func Grouped() error {
+	var g check.Group
+	g.Go(func() {
+		check.Must(errOops)
+	})
+	return g.Wait()
+}
+
+func CaughtCtx(ctx context.Context) error {
+	return check.CatchCtx(ctx, func() {
+		check.Must(errOops)
+	})
+}
+
+func Cancellable(ctx context.Context) { // want `Cancellable can leak a check failure via check.MustCtx`
+	check.MustCtx(ctx)
+}
+
+func HandledAll(s string) (_ int, e error) {
+	defer check.HandleAll(&e)
+	return check.Must1(strconv.Atoi(s)), nil
+}
+
+func CaughtAll() error {
+	return check.CatchAll(func() {
+		check.Must(errOops)
+	})
+}
+
+func Scoped(s string) (_ int, e error) {
+	var sc check.Scope
+	defer sc.Handle(&e)
+	return check.Must1(strconv.Atoi(s)), nil
+}
+
+func Lookup(m map[string]any, k string) string { // want `Lookup can leak a check failure via check.Cast`
+	return check.Cast[string](check.Key(m, k))
+}
+
+func Sum(seq iter.Seq2[int, error]) (n int) { // want `Sum can leak a check failure via check.Values`
+	for i := range check.Values(seq) {
+		n += i
+	}
+	return n
+}
+
+func Parsed(ss []string) iter.Seq2[int, error] {
+	return check.CatchSeq(func(yield func(int) bool) {
+		for _, s := range ss {
+			if !yield(check.Must1(strconv.Atoi(s))) {
+				return
+			}
+		}
+	})
+}
+
+func Retried(ctx context.Context, name string) error {
+	return check.Retry(ctx, check.Policy{MaxAttempts: 3}, func() {
+		check.Must(os.Remove(name))
+	})
+}
+
+func Retried1(ctx context.Context, s string) (int, error) {
+	return check.Retry1(ctx, check.Policy{}, func() int {
+		return check.Must1(strconv.Atoi(s))
+	})
+}
+
+func RetryPolicy(ctx context.Context, s string) error { // want `RetryPolicy can leak a check failure via check.Must1`
+	return check.Retry(ctx, check.Policy{MaxAttempts: check.Must1(strconv.Atoi(s))}, func() {})
+}
+
+func Served() http.Handler {
+	return checkhttp.Handler(func(w http.ResponseWriter, r *http.Request) {
+		check.Must1(w.Write([]byte(r.URL.Path)))
+	})
+}
+
+func ServedError() http.Handler {
+	return checkhttp.ErrorHandler(func(w http.ResponseWriter, r *http.Request) error {
+		check.Must(os.Remove(r.URL.Path))
+		return nil
+	})
+}
+
+func Tested(t *testing.T, s string) {
+	checktest.Run(t, func() {
+		check.Must1(strconv.Atoi(s))
+	})
+}
+
+func TestedFails(t *testing.T, s string) bool {
+	return checktest.Fails(t, func() {
+		check.Must1(strconv.Atoi(s))
+	}, strconv.ErrSyntax)
+}
+
+func TestedValue(t *testing.T, s string) int {
+	return checktest.Must1(strconv.Atoi(s))(t)
+}
@@ -0,0 +1,49 @@
+package fix
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/goeezi/check"
+)
+
+var errOops = errors.New("oops")
+
+func Unnamed(s string) (int, error) { // want `Unnamed can leak`
+	n := check.Must1(strconv.Atoi(s))
+	return n * 2, nil
+}
+
+func NamedError(s string) (n int, err error) { // want `NamedError can leak`
+	n = check.Must1(strconv.Atoi(s))
+	return
+}
+
+func Blank(s string) (a, b int, _ error) { // want `Blank can leak`
+	a = check.Must1(strconv.Atoi(s))
+	return a, a, nil
+}
+
+func NoError(s string) int { // want `NoError can leak`
+	if s == "" {
+		return 0
+	}
+	return check.Must1(strconv.Atoi(s))
+}
+
+func NoResults() { // want `NoResults can leak`
+	check.Must(errOops)
+}
+
+func Taken(e string) (int, error) { // want `Taken can leak`
+	return check.Must1(strconv.Atoi(e)), nil
+}
+
+func pair() (int, int) {
+	check.Must(errOops)
+	return 1, 2
+}
+
+func Forward() (int, int) { // want `Forward can leak`
+	return pair()
+}
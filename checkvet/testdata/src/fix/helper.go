@@ -0,0 +1,8 @@
+package fix
+
+import "github.com/goeezi/check"
+
+func helper() int {
+	check.Must(errOops)
+	return 1
+}
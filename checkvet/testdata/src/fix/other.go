@@ -0,0 +1,9 @@
+package fix
+
+func Indirect() int { // want `Indirect can leak`
+	return helper()
+}
+
+func Indirect2() { // want `Indirect2 can leak`
+	helper()
+}
@@ -0,0 +1,64 @@
+// Package check is a stub of github.com/goeezi/check for analyzer tests.
+package check
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+)
+
+var ErrNilError = fmt.Errorf("called Fail(nil)")
+
+type Error struct{ err error }
+
+func (e Error) Error() string { return e.err.Error() }
+
+func Must(err error)                                                    {}
+func Must1[T any](t T, err error) T                                     { return t }
+func Must2[T1, T2 any](t1 T1, t2 T2, err error) (T1, T2)                { return t1, t2 }
+func Must3[T1, T2, T3 any](t1 T1, t2 T2, t3 T3, err error) (T1, T2, T3) { return t1, t2, t3 }
+func Fail(err error)                                                    {}
+func Failf(format string, args ...any)                                  { _ = fmt.Errorf(format, args...) }
+func Handle(e *error, transforms ...func(e error) error)                {}
+func Wrap(e *error, skip int, transforms ...func(error) error)          {}
+func Catch(work func(), transforms ...func(e error) error) error        { return nil }
+func Catch1[T any](work func() T, transforms ...func(e error) error) (T, error) {
+	return work(), nil
+}
+
+func Close(e *error, c io.Closer)            {}
+func CloseFunc(e *error, close func() error) {}
+
+type Group struct{}
+
+func (g *Group) Go(work func(), transforms ...func(e error) error) {}
+func (g *Group) Wait() error                                       { return nil }
+
+func MustCtx(ctx context.Context) {}
+func CatchCtx(ctx context.Context, work func(), transforms ...func(e error) error) error {
+	return nil
+}
+
+func HandleAll(e *error, transforms ...func(e error) error)         {}
+func CatchAll(work func(), transforms ...func(e error) error) error { return nil }
+
+type Scope struct{}
+
+func (s *Scope) OnFailure(undo func() error)                        {}
+func (s *Scope) Handle(e *error, transforms ...func(e error) error) {}
+
+func Cast[T any](x any) T                             { return x.(T) }
+func Key[M ~map[K]V, K comparable, V any](m M, k K) V { return m[k] }
+
+func Values[T any](seq iter.Seq2[T, error]) iter.Seq[T] { return nil }
+func CatchSeq[T any](seq iter.Seq[T], transforms ...func(e error) error) iter.Seq2[T, error] {
+	return nil
+}
+
+type Policy struct{ MaxAttempts int }
+
+func Retry(ctx context.Context, p Policy, work func()) error { return nil }
+func Retry1[T any](ctx context.Context, p Policy, work func() T) (T, error) {
+	return work(), nil
+}
@@ -0,0 +1,15 @@
+// Package checkhttp is a stub of github.com/goeezi/check/checkhttp for
+// analyzer tests.
+package checkhttp
+
+import "net/http"
+
+type Option func()
+
+func Handler(h func(w http.ResponseWriter, r *http.Request), opts ...Option) http.Handler {
+	return nil
+}
+
+func ErrorHandler(h func(w http.ResponseWriter, r *http.Request) error, opts ...Option) http.Handler {
+	return nil
+}
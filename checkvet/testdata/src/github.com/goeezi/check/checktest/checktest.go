@@ -0,0 +1,10 @@
+// Package checktest is a stub of github.com/goeezi/check/checktest for
+// analyzer tests.
+package checktest
+
+import "testing"
+
+func Must(t testing.TB, err error)                       {}
+func Must1[T any](t T, err error) func(tb testing.TB) T  { return nil }
+func Run(t testing.TB, work func())                      {}
+func Fails(t testing.TB, work func(), target error) bool { return true }
@@ -0,0 +1,87 @@
+package handle
+
+import (
+	"errors"
+
+	"github.com/goeezi/check"
+)
+
+var errOops = errors.New("oops")
+
+func Deferred() (e error) {
+	defer check.Handle(&e)
+	check.Must(errOops)
+	return nil
+}
+
+func Parenthesized() (e error) {
+	defer check.Wrap((&e), 0)
+	check.Must(errOops)
+	return nil
+}
+
+func NotDeferred() (e error) {
+	check.Handle(&e) // want `check.Handle must be deferred; called directly, it can't recover a failure`
+	check.Must(errOops)
+	return nil
+}
+
+func Nested() (e error) {
+	defer func() {
+		check.Wrap(&e, 0) // want `check.Wrap must be deferred directly; recover has no effect in a nested call`
+	}()
+	check.Must(errOops)
+	return nil
+}
+
+func Local() error {
+	var err error
+	defer check.Handle(&err) // want `check.Handle should be given a pointer to a named error result of the enclosing function; otherwise the error is lost`
+	check.Must(errOops)
+	return err
+}
+
+func Outer() (e error) {
+	func() {
+		defer check.Handle(&e) // want `check.Handle should be given a pointer to a named error result`
+		check.Must(errOops)
+	}()
+	return nil
+}
+
+func Pointer(e *error) {
+	defer check.Handle(e) // want `check.Handle should be given a pointer to a named error result`
+	check.Must(errOops)
+}
+
+func Nil() error {
+	defer check.Handle(nil) // want `check.Handle\(nil\) re-panics failures in a function that returns an error`
+	check.Must(errOops)
+	return nil
+}
+
+func NilNoError() {
+	defer check.Handle(nil, func(error) error { return nil })
+	check.Must(errOops)
+}
+
+func Literal() {
+	_ = func() (n int, e error) {
+		defer check.Handle(&e)
+		check.Must(errOops)
+		return 42, nil
+	}
+}
+
+func AllNotDeferred() (e error) {
+	check.HandleAll(&e) // want `check.HandleAll must be deferred; called directly, it can't recover a failure`
+	check.Must(errOops)
+	return nil
+}
+
+func ScopeNotDeferred() (e error) {
+	var s check.Scope
+	s.Handle(&e) // want `check.Scope.Handle must be deferred; called directly, it can't recover a failure`
+	check.Must(errOops)
+	return nil
+}
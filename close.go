@@ -0,0 +1,46 @@
+package check
+
+import "io"
+
+// Close, when deferred after Handle or Wrap, closes c and merges any error
+// from doing so into the handled error. If the function succeeded, *e is set
+// to the close error. If it is failing, the close error is joined with the
+// failure, as errors.Join does, before Handle sees it. If e is nil, a close
+// error is raised as a failure.
+//
+//	func save(name string, data []byte) (e error) {
+//		defer check.Handle(&e)
+//		f := check.Must1(os.Create(name))
+//		defer check.Close(&e, f)
+//		check.Must1(f.Write(data))
+//		return nil
+//	}
+func Close(e *error, c io.Closer) {
+	closeWith(recover(), e, c.Close)
+}
+
+// CloseFunc behaves like Close, but calls close instead of a Close method.
+//
+//	defer check.CloseFunc(&e, tx.Rollback)
+func CloseFunc(e *error, close func() error) {
+	closeWith(recover(), e, close)
+}
+
+func closeWith(r any, e *error, close func() error) {
+	err := close()
+	if r != nil {
+		if failure, is := r.(Error); is && err != nil {
+			r = Error{err: join(failure.err, err), pc: failure.pc}
+		}
+		panic(r)
+	}
+	switch {
+	case err == nil:
+	case e == nil:
+		panic(Error{err: err})
+	case *e == nil:
+		*e = err
+	default:
+		*e = join(*e, err)
+	}
+}
@@ -0,0 +1,113 @@
+package check_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+
+type closer struct {
+	err    error
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return c.err
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	use := func(c *closer, fail error) (e error) {
+		defer check.Handle(&e)
+		defer check.Close(&e, c)
+		if fail != nil {
+			check.Fail(fail)
+		}
+		return nil
+	}
+
+	c := &closer{}
+	assert.NoError(t, use(c, nil))
+	assert.True(t, c.closed)
+
+	c = &closer{err: errOther}
+	assert.Equal(t, errOther, use(c, nil))
+	assert.True(t, c.closed)
+
+	c = &closer{}
+	assert.Equal(t, errOops, use(c, errOops))
+	assert.True(t, c.closed)
+
+	c = &closer{err: errOther}
+	err := use(c, errOops)
+	assert.EqualError(t, err, "oops\nother")
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+}
+
+func TestCloseReturnedError(t *testing.T) {
+	t.Parallel()
+
+	err := func() (e error) {
+		defer check.Close(&e, &closer{err: errOther})
+		return errOops
+	}()
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+}
+
+func TestCloseTransform(t *testing.T) {
+	t.Parallel()
+
+	err := func() (e error) {
+		defer check.Handle(&e, check.Prefix("saving"))
+		defer check.Close(&e, &closer{err: errOther})
+		check.Fail(errOops)
+		return nil
+	}()
+	assert.EqualError(t, err, "saving: oops\nother")
+}
+
+func TestCloseWrap(t *testing.T) {
+	t.Parallel()
+
+	err := func() (e error) {
+		defer check.Wrap(&e, 0)
+		defer check.Close(&e, &closer{err: errOther})
+		check.Fail(errOops)
+		return nil
+	}()
+	var serr *check.StackError
+	require.True(t, errors.As(err, &serr))
+	assert.Equal(t, "github.com/goeezi/check_test.TestCloseWrap.func1", serr.Frames()[0].Function)
+}
+
+func TestCloseFunc(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, errOther, func() (e error) {
+		defer check.Handle(&e)
+		defer check.CloseFunc(&e, func() error { return errOther })
+		return nil
+	}())
+
+	assert.PanicsWithError(t, "other", func() {
+		defer check.CloseFunc(nil, func() error { return errOther })
+	})
+
+	closed := false
+	assert.PanicsWithValue(t, 42, func() {
+		defer check.CloseFunc(nil, func() error {
+			closed = true
+			return errOther
+		})
+		panic(42)
+	})
+	assert.True(t, closed)
+}
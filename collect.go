@@ -0,0 +1,37 @@
+package check
+
+import "sync"
+
+// Collector accumulates errors instead of failing on the first one, for
+// callers that would rather report every problem at once, such as validators
+// and batch imports. The zero value is an empty Collector. A Collector is safe
+// for concurrent use.
+//
+//	var c check.Collector
+//	for _, row := range rows {
+//		c.Must(validate(row))
+//	}
+//	return c.Err()
+type Collector struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Must records err if it is not nil and reports whether it was nil.
+func (c *Collector) Must(err error) bool {
+	if err == nil {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+	return false
+}
+
+// Err returns nil if no errors have been recorded, the error if there is only
+// one, or all of them joined as by errors.Join.
+func (c *Collector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return join(c.errs...)
+}
@@ -0,0 +1,83 @@
+package check_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goeezi/check"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	var c check.Collector
+	assert.NoError(t, c.Err())
+	assert.True(t, c.Must(nil))
+	assert.NoError(t, c.Err())
+
+	assert.False(t, c.Must(errOops))
+	assert.False(t, c.Must(errOther))
+	err := c.Err()
+	assert.EqualError(t, err, "oops\nother")
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+}
+
+func TestCollectorConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var c check.Collector
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Must(errOops)
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, c.Err().(interface{ Unwrap() []error }).Unwrap(), 10)
+}
+
+func TestCollectN(t *testing.T) {
+	t.Parallel()
+
+	var c check.Collector
+	assert.Equal(t, 42, check.Collect1(strconv.Atoi("42"))(&c))
+	assert.Equal(t, 0, check.Collect1(strconv.Atoi("x"))(&c))
+	q, r := check.Collect2(divmod(7, 2))(&c)
+	assert.Equal(t, []float64{3, 1}, []float64{q, r})
+	m, q, r := check.Collect3(muldivmod(0, 0))(&c)
+	assert.Equal(t, float64(0), m, "%v %v", q, r)
+	o, h, l, cl := check.Collect4(analyzeTrades(3, 1, 4))(&c)
+	assert.Equal(t, []float64{3, 4, 1, 4}, []float64{o, h, l, cl})
+	assert.EqualError(t, c.Err(),
+		"strconv.Atoi: parsing \"x\": invalid syntax\ncannot muldivmod(0, 0)")
+}
+
+func TestFailAll(t *testing.T) {
+	t.Parallel()
+
+	assert.NotPanics(t, func() {
+		check.FailAll()
+		check.FailAll(nil, nil)
+	})
+
+	err := check.Catch(func() {
+		check.FailAll(nil, errOops, nil, errOther)
+	})
+	assert.EqualError(t, err, "oops\nother")
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+
+	err = func() (e error) {
+		defer check.Wrap(&e, 0)
+		check.FailAll(errOops, errOther)
+		return nil
+	}()
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+}
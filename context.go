@@ -0,0 +1,92 @@
+package check
+
+import (
+	"context"
+	"errors"
+)
+
+// ContextError is the error with which MustCtx and the CatchCtx… family fail
+// when their context is done. It unwraps to the context's cause, which is the
+// same as its error unless the context was canceled with a cause, followed by
+// the error with which work failed, if any, and it matches context.Canceled or
+// context.DeadlineExceeded via errors.Is, letting transforms distinguish
+// cancellation from other failures.
+//
+//	defer check.Handle(&e, func(e error) error {
+//		var ce *check.ContextError
+//		if errors.As(e, &ce) {
+//			return nil // The caller gave up; nothing to report.
+//		}
+//		return e
+//	})
+type ContextError struct {
+	err   error
+	cause error
+	orig  error
+}
+
+// newContextError returns a ContextError for ctx, which is done, reporting
+// orig, if not nil, as the failure it caused.
+func newContextError(ctx context.Context, orig error) *ContextError {
+	return &ContextError{err: ctx.Err(), cause: context.Cause(ctx), orig: orig}
+}
+
+// Error returns the cause's message, thus implementing the error interface.
+func (e *ContextError) Error() string {
+	return e.cause.Error()
+}
+
+// Unwrap returns the context's cause and the error with which work failed, if
+// any, as required by the errors packages.
+func (e *ContextError) Unwrap() []error {
+	if e.orig == nil {
+		return []error{e.cause}
+	}
+	return []error{e.cause, e.orig}
+}
+
+// Is reports whether target is the context's error, such as context.Canceled.
+func (e *ContextError) Is(target error) bool {
+	return target == e.err
+}
+
+// MustCtx calls panic(Error{err}) if ctx is done, where err is a ContextError
+// for ctx's cause.
+//
+//	for _, item := range items {
+//		check.MustCtx(ctx)
+//		check.Must(process(item))
+//	}
+func MustCtx(ctx context.Context) {
+	if ctx.Err() != nil {
+		raise(newContextError(ctx, nil))
+	}
+}
+
+// CatchCtx behaves like Catch, but fails without calling work if ctx is
+// already done. If work fails after ctx is done, the failure is reported as a
+// ContextError since it is most likely a consequence of the cancellation. The
+// ContextError keeps work's error in its chain, and transforms see it.
+//
+//	return check.CatchCtx(ctx, func() {
+//		rows := check.Must1(db.QueryContext(ctx, q))
+//		…
+//	})
+func CatchCtx(ctx context.Context, work func(), transforms ...func(e error) error) (e error) {
+	defer Handle(&e, ctxTransforms(ctx, transforms)...)
+	MustCtx(ctx)
+	work()
+	return
+}
+
+// ctxTransforms prepends to transforms one that reports failures after ctx is
+// done as a ContextError.
+func ctxTransforms(ctx context.Context, transforms []func(e error) error) []func(e error) error {
+	return append([]func(e error) error{func(e error) error {
+		var ce *ContextError
+		if ctx.Err() != nil && !errors.As(e, &ce) {
+			return newContextError(ctx, e)
+		}
+		return e
+	}}, transforms...)
+}
@@ -0,0 +1,103 @@
+package check_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+
+func TestMustCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NotPanics(t, func() {
+		check.MustCtx(ctx)
+	})
+
+	cancel()
+	err := check.Catch(func() {
+		check.MustCtx(ctx)
+	})
+	assert.EqualError(t, err, "context canceled")
+	assert.ErrorIs(t, err, context.Canceled)
+	var ce *check.ContextError
+	assert.True(t, errors.As(err, &ce))
+}
+
+func TestCatchCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	assert.NoError(t, check.CatchCtx(ctx, func() {}))
+	assert.ErrorIs(t, check.CatchCtx(ctx, func() { check.Fail(errOops) }), errOops)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	called := false
+	err := check.CatchCtx(ctx, func() { called = true })
+	assert.False(t, called)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCatchCtxDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	// A failure after the deadline is reported as the deadline, keeping the
+	// original failure, and transforms can tell.
+	canceled := false
+	err := check.CatchCtx(ctx, func() {
+		<-ctx.Done()
+		check.Fail(errOops)
+	}, func(e error) error {
+		var ce *check.ContextError
+		canceled = errors.As(e, &ce)
+		return e
+	})
+	assert.True(t, canceled)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, errOops)
+	assert.NotErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, "context deadline exceeded")
+
+	// Work doesn't start once the deadline has passed.
+	i, err := check.CatchCtx1(ctx, func() int { return 42 })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, i)
+}
+
+func TestCatchCtxN(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	i, err := check.CatchCtx1(ctx, func() int { return 1 })
+	require.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	a, b, err := check.CatchCtx2(ctx, func() (int, int) { return 1, 2 })
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, []int{a, b})
+
+	a, b, c, err := check.CatchCtx3(ctx, func() (int, int, int) { return 1, 2, 3 })
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, []int{a, b, c})
+
+	a, b, c, d, err := check.CatchCtx4(ctx, func() (int, int, int, int) { return 1, 2, 3, 4 })
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{a, b, c, d})
+
+	_, _, _, _, err = check.CatchCtx4(ctx, func() (int, int, int, int) {
+		check.Fail(errOops)
+		return 1, 2, 3, 4
+	})
+	assert.ErrorIs(t, err, errOops)
+}
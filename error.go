@@ -1,13 +1,19 @@
 package check
 
-import "errors"
+import (
+	"errors"
+	"runtime"
+	"strings"
+)
 
 var ErrNilError = errors.New("called Fail(nil)")
 
 // Error wraps an error. The Must… family of functions use Error to wrap errors
-// in calls to panic, while the Catch… family detect errors wrapped thus.
+// in calls to panic, while the Catch… family detect errors wrapped thus. Error
+// also records where the failure was raised, as reported by Location.
 type Error struct {
 	err error
+	pc  uintptr
 }
 
 // Error returns a string representation of e, thus implementing the error
@@ -20,3 +26,146 @@ func (e Error) Error() string {
 func (e Error) Unwrap() error {
 	return e.err
 }
+
+// raise panics with Error{err}, recording the caller of raise's caller as
+// the location of the failure.
+func raise(err error) {
+	var pc [1]uintptr
+	runtime.Callers(3, pc[:])
+	panic(Error{err: err, pc: pc[0]})
+}
+
+// raiseNew behaves like raise for an error created by raise's caller, such as
+// a formatted message, which also carries the location so that it survives
+// recovery.
+func raiseNew(err error) {
+	var pc [1]uintptr
+	runtime.Callers(3, pc[:])
+	panic(Error{err: &located{err: err, pc: pc[0]}, pc: pc[0]})
+}
+
+// located is an error that records where a failure was raised.
+type located struct {
+	err error
+	pc  uintptr
+}
+
+func (e *located) Error() string {
+	return e.err.Error()
+}
+
+func (e *located) Unwrap() error {
+	return e.err
+}
+
+// Location returns where the failure in err's chain was raised: the file,
+// line and function of the Must…, Fail… or similar call, the panic recovered
+// by HandleAll or CatchAll, or the top of a StackError's stack. It returns
+// zero values if err doesn't record a location.
+//
+// Handle and Catch return errors unchanged unless a transform replaces them,
+// so the location is only recorded in errors that a transform wraps, such as
+// with Prefix or Located, errors created by package check, such as with Failf
+// or Mustf, and errors recovered by Wrap.
+//
+//	if file, line, _ := check.Location(err); file != "" {
+//		log.Printf("%s:%d: %v", file, line, err)
+//	}
+func Location(err error) (file string, line int, fn string) {
+	var frame runtime.Frame
+	var find func(err error) bool
+	find = func(err error) bool {
+		switch e := err.(type) {
+		case nil:
+			return false
+		case Error:
+			if e.pc != 0 {
+				frame, _ = runtime.CallersFrames([]uintptr{e.pc}).Next()
+				return true
+			}
+		case *located:
+			frame, _ = runtime.CallersFrames([]uintptr{e.pc}).Next()
+			return true
+		case *StackError:
+			if len(e.frames) > 0 {
+				frame = e.frames[0]
+				return true
+			}
+		case *PanicError:
+			if len(e.frames) > 0 {
+				frame = e.frames[0]
+				return true
+			}
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			return find(u.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, err := range u.Unwrap() {
+				if find(err) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if !find(err) {
+		return "", 0, ""
+	}
+	return frame.File, frame.Line, frame.Function
+}
+
+// Located returns a transform that records where the failure was raised in
+// errors that don't record a location yet, so that Location reports it once
+// Handle or Catch has returned the error. It doesn't change the error's
+// message or what errors.Is and errors.As find.
+//
+//	defer check.Handle(&e, check.Located())
+func Located() func(e error) error {
+	return func(e error) error {
+		if file, _, _ := Location(e); file != "" {
+			return e
+		}
+		if frame, ok := raiser(); ok {
+			// Like the PCs returned by runtime.Callers, located.pc is the
+			// return address, one past the frame's PC.
+			return &located{err: e, pc: frame.PC + 1}
+		}
+		return e
+	}
+}
+
+// join behaves like errors.Join, except that a lone error is returned
+// unchanged. It returns an error wrapping the non-nil errors in errs, or nil
+// if there are none.
+func join(errs ...error) error {
+	var e joinError
+	for _, err := range errs {
+		if err != nil {
+			e = append(e, err)
+		}
+	}
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return e[0]
+	}
+	return e
+}
+
+// joinError is a list of errors, formatted one per line.
+type joinError []error
+
+func (e joinError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors in e as required by the errors packages.
+func (e joinError) Unwrap() []error {
+	return e
+}
@@ -2,6 +2,11 @@ package check_test
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,7 +14,10 @@ import (
 	"github.com/goeezi/check"
 )
 
-var errOops = errors.New("oops")
+var (
+	errOops  = errors.New("oops")
+	errOther = errors.New("other")
+)
 
 func TestError(t *testing.T) {
 	t.Parallel()
@@ -18,3 +26,146 @@ func TestError(t *testing.T) {
 		check.Must(errOops)
 	})
 }
+
+// here returns the caller's line.
+func here() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestLocation(t *testing.T) {
+	t.Parallel()
+
+	const fn = "github.com/goeezi/check_test.TestLocation.func1"
+	var line int
+	err := check.Catch(func() {
+		line = here() + 1
+		check.Must1(strconv.Atoi("x"))
+	}, check.Prefix("parsing"))
+	file, l, f := check.Location(err)
+	assert.True(t, strings.HasSuffix(file, "error_test.go"), file)
+	assert.Equal(t, line, l)
+	assert.Equal(t, fn, f)
+
+	// The location survives further wrapping.
+	_, l, _ = check.Location(fmt.Errorf("outer: %w", err))
+	assert.Equal(t, line, l)
+}
+
+func TestLocationUnchanged(t *testing.T) {
+	t.Parallel()
+
+	// Errors returned unchanged keep their identity and have no location.
+	err := check.Catch(func() { check.Fail(errOops) })
+	assert.Equal(t, errOops, err)
+	file, line, fn := check.Location(err)
+	assert.Equal(t, "", file)
+	assert.Equal(t, 0, line)
+	assert.Equal(t, "", fn)
+
+	err = check.Catch(func() { check.Fail(errOops) }, check.Replace(errOops, errOther))
+	assert.Equal(t, errOther, err)
+	_, line, _ = check.Location(err)
+	assert.Equal(t, 0, line)
+}
+
+func TestLocationCreated(t *testing.T) {
+	t.Parallel()
+
+	var lines []int
+	errs := []error{
+		check.Catch(func() {
+			lines = append(lines, here()+1)
+			check.Failf("bad %d", 42)
+		}),
+		check.Catch(func() {
+			lines = append(lines, here()+1)
+			check.Must1f(strconv.Atoi("x"))("parsing")
+		}),
+		check.Catch(func() {
+			lines = append(lines, here()+1)
+			check.Mustf(errOops)("doing")
+		}),
+	}
+	for i, err := range errs {
+		_, line, _ := check.Location(err)
+		assert.Equal(t, lines[i], line, err)
+	}
+	assert.EqualError(t, errs[0], "bad 42")
+}
+
+func TestLocationRecovered(t *testing.T) {
+	t.Parallel()
+
+	var line int
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			_, l, _ := check.Location(err)
+			assert.Equal(t, line, l)
+		}()
+		line = here() + 1
+		check.Fail(errOops)
+	}()
+
+	// Errors created by package check record their location even when
+	// recovered directly.
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			_, l, _ := check.Location(err)
+			assert.Equal(t, line, l)
+		}()
+		line = here() + 1
+		check.Failf("oops")
+	}()
+
+	err := func() (e error) {
+		defer check.Wrap(&e, 0)
+		line = here() + 1
+		check.Fail(errOops)
+		return nil
+	}()
+	_, l, _ := check.Location(err)
+	assert.Equal(t, line, l)
+
+	err = check.CatchAll(func() {
+		line = here() + 1
+		panic(42)
+	})
+	_, l, _ = check.Location(err)
+	assert.Equal(t, line, l)
+}
+
+func TestLocated(t *testing.T) {
+	t.Parallel()
+
+	var line int
+	parse := func(a, b, c string) (_ int, e error) {
+		defer check.Handle(&e, check.Located())
+		x := check.Must1(strconv.Atoi(a))
+		y := check.Must1(strconv.Atoi(b))
+		line = here() + 1
+		z := check.Must1(strconv.Atoi(c))
+		return x + y + z, nil
+	}
+	_, err := parse("1", "2", "x")
+	assert.EqualError(t, err, `strconv.Atoi: parsing "x": invalid syntax`)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	file, l, fn := check.Location(err)
+	assert.Equal(t, "error_test.go", filepath.Base(file))
+	assert.Equal(t, line, l)
+	assert.Equal(t, "github.com/goeezi/check_test.TestLocated.func1", fn)
+
+	// Errors that already record a location are left alone.
+	err = check.Catch(func() {
+		line = here() + 1
+		check.Failf("oops")
+	}, check.Located())
+	_, l, _ = check.Location(err)
+	assert.Equal(t, line, l)
+
+	// Called other than while recovering a failure, Located has nothing to
+	// record.
+	assert.Same(t, errOops, check.Located()(errOops))
+}
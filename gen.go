@@ -0,0 +1,365 @@
+//go:build ignore
+
+// Gen generates the N-ary families of functions in package check, such as
+// Must1…MustN and Catch1…CatchN, along with their tests and benchmarks, the
+// MustN family in package checktest and the highest arity, which checkvet's
+// rewriting commands assume when they can't load package check.
+//
+// Usage:
+//
+//	go run gen.go [-max n]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// arity describes the functions with n results besides the error.
+type arity int
+
+func (n arity) N() int { return int(n) }
+
+// list returns the n elements produced by f, joined with ", ".
+func (n arity) list(f func(i int) string) string {
+	elems := make([]string, n)
+	for i := range elems {
+		elems[i] = f(i + 1)
+	}
+	return strings.Join(elems, ", ")
+}
+
+// Types returns "T" or "T1, T2, …".
+func (n arity) Types() string {
+	if n == 1 {
+		return "T"
+	}
+	return n.list(func(i int) string { return fmt.Sprintf("T%d", i) })
+}
+
+// Vals returns "t" or "t1, t2, …".
+func (n arity) Vals() string {
+	if n == 1 {
+		return "t"
+	}
+	return n.list(func(i int) string { return fmt.Sprintf("t%d", i) })
+}
+
+// Params returns "t T" or "t1 T1, t2 T2, …".
+func (n arity) Params() string {
+	if n == 1 {
+		return "t T"
+	}
+	return n.list(func(i int) string { return fmt.Sprintf("t%d T%d", i, i) })
+}
+
+// Results returns "T" or "(T1, T2, …)".
+func (n arity) Results() string {
+	if n == 1 {
+		return "T"
+	}
+	return "(" + n.Types() + ")"
+}
+
+// Blanks returns "_, _, …".
+func (n arity) Blanks() string {
+	return n.list(func(int) string { return "_" })
+}
+
+// Vars returns "v1, v2, …" for use in tests.
+func (n arity) Vars() string {
+	return n.list(func(i int) string { return fmt.Sprintf("v%d", i) })
+}
+
+// Ints returns "int, int, …" for use in tests.
+func (n arity) Ints() string {
+	return n.list(func(int) string { return "int" })
+}
+
+// Lits returns "1, 2, …" for use in tests.
+func (n arity) Lits() string {
+	return n.list(func(i int) string { return fmt.Sprint(i) })
+}
+
+// Long reports whether signatures should be split across lines.
+func (n arity) Long() bool {
+	return n > 2
+}
+
+const header = `// Code generated by gen.go; DO NOT EDIT.
+
+`
+
+var src = template.Must(template.New("src").Parse(header + `package check
+
+import "context"
+{{range .}}
+// Must{{.N}} returns {{.Vals}} if err is nil, otherwise it calls panic(Error{err}).
+{{- if eq .N 1}}
+//
+//	price := check.Must1(strconv.ParseFloat(unitPrice, 64)) *
+//		check.Must1(strconv.ParseFloat(qty, 64))
+{{- end}}
+{{- if .Long}}
+func Must{{.N}}[{{.Types}} any](
+	{{.Params}}, err error,
+) {{.Results}} {
+{{- else}}
+func Must{{.N}}[{{.Types}} any]({{.Params}}, err error) {{.Results}} {
+{{- end}}
+	if err != nil {
+		raise(err)
+	}
+	return {{.Vals}}
+}
+{{end}}
+{{- range .}}
+// Must{{.N}}f returns a function that returns {{.Vals}} if err is nil, otherwise it
+// calls panic(Error{fmt.Errorf(format+": %w", args..., err)}).
+{{- if eq .N 1}}
+//
+//	cfg := check.Must1f(os.Open(name))("opening config %s", name)
+{{- end}}
+{{- if .Long}}
+func Must{{.N}}f[{{.Types}} any](
+	{{.Params}}, err error,
+) func(format string, args ...any) {{.Results}} {
+{{- else}}
+func Must{{.N}}f[{{.Types}} any]({{.Params}}, err error) func(format string, args ...any) {{.Results}} {
+{{- end}}
+	return func(format string, args ...any) {{.Results}} {
+		if err != nil {
+			raiseNew(annotate(err, format, args))
+		}
+		return {{.Vals}}
+	}
+}
+{{end}}
+{{- range .}}
+// Catch{{.N}} returns {{.Blanks}}, err if calling work panics with Error{err},
+// otherwise it returns {{.Vals}}, nil.
+{{- if eq .N 1}}
+//
+//	func getTotalWeight(weight, qty string) (float64, error) {
+//		return Catch1(func() float64 {
+//			return Must1(strconv.ParseFloat(weight, 64)) *
+//				float64(Must1(strconv.Atoi(qty)))
+//		})
+//	}
+{{- else}} See Catch1 for a related example.
+{{- end}}
+func Catch{{.N}}[{{.Types}} any](
+	work func() {{.Results}},
+	transforms ...func(e error) error,
+) ({{.Params}}, e error) {
+	defer Handle(&e, transforms...)
+	{{.Vals}} = work()
+	return
+}
+{{end}}
+{{- range .}}
+// CatchCtx{{.N}} behaves like Catch{{.N}} with the context handling of CatchCtx.
+func CatchCtx{{.N}}[{{.Types}} any](
+	ctx context.Context,
+	work func() {{.Results}},
+	transforms ...func(e error) error,
+) ({{.Params}}, e error) {
+	defer Handle(&e, ctxTransforms(ctx, transforms)...)
+	MustCtx(ctx)
+	{{.Vals}} = work()
+	return
+}
+{{end}}
+{{- range .}}
+// Collect{{.N}} returns a function that returns {{.Vals}} after recording err in c
+// if it is not nil.
+{{- if eq .N 1}}
+//
+//	port := check.Collect1(strconv.Atoi(cfg.Port))(&c)
+{{- end}}
+{{- if .Long}}
+func Collect{{.N}}[{{.Types}} any](
+	{{.Params}}, err error,
+) func(c *Collector) {{.Results}} {
+{{- else}}
+func Collect{{.N}}[{{.Types}} any]({{.Params}}, err error) func(c *Collector) {{.Results}} {
+{{- end}}
+	return func(c *Collector) {{.Results}} {
+		c.Must(err)
+		return {{.Vals}}
+	}
+}
+{{end}}`))
+
+var test = template.Must(template.New("test").Parse(header + `package check_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+{{range .}}
+func TestArity{{.N}}(t *testing.T) {
+	t.Parallel()
+
+	want := []int{ {{- .Lits -}} }
+	succeed := func() ({{.Ints}}) {
+		return check.Must{{.N}}({{.Lits}}, nil)
+	}
+	fail := func() ({{.Ints}}) {
+		return check.Must{{.N}}({{.Lits}}, errOops)
+	}
+
+	{{.Vars}}, err := check.Catch{{.N}}(succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{ {{- .Vars -}} })
+	{{.Blanks}}, err = check.Catch{{.N}}(fail)
+	assert.Equal(t, errOops, err)
+
+	{{.Vars}}, err = check.CatchCtx{{.N}}(context.Background(), succeed)
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{ {{- .Vars -}} })
+	{{.Blanks}}, err = check.CatchCtx{{.N}}(context.Background(), fail)
+	assert.Equal(t, errOops, err)
+
+	{{.Vars}}, err = check.Catch{{.N}}(func() ({{.Ints}}) {
+		return check.Must{{.N}}f({{.Lits}}, nil)("arity %d", {{.N}})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, []int{ {{- .Vars -}} })
+	{{.Blanks}}, err = check.Catch{{.N}}(func() ({{.Ints}}) {
+		return check.Must{{.N}}f({{.Lits}}, errOops)("arity %d", {{.N}})
+	})
+	assert.EqualError(t, err, "arity {{.N}}: oops")
+
+	var c check.Collector
+	{{.Vars}} = check.Collect{{.N}}({{.Lits}}, errOops)(&c)
+	assert.Equal(t, want, []int{ {{- .Vars -}} })
+	assert.Equal(t, errOops, c.Err())
+}
+{{end}}
+{{- range .}}
+func BenchmarkSuccessMust{{.N}}(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		check.Must{{.N}}({{.Lits}}, nil)
+	}
+}
+
+func BenchmarkSuccessCatch{{.N}}(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		{{.Blanks}}, _ = check.Catch{{.N}}(func() ({{.Ints}}) {
+			return check.Must{{.N}}({{.Lits}}, nil)
+		})
+	}
+}
+
+func BenchmarkFailureCatch{{.N}}(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		{{.Blanks}}, _ = check.Catch{{.N}}(func() ({{.Ints}}) {
+			return check.Must{{.N}}({{.Lits}}, errOops)
+		})
+	}
+}
+{{end}}`))
+
+var checktest = template.Must(template.New("checktest").Parse(header + `package checktest
+
+import "testing"
+{{range .}}
+// Must{{.N}} returns a function that returns {{.Vals}} if err is nil, otherwise it
+// calls tb.Fatal(err).
+{{- if eq .N 1}}
+//
+//	f := checktest.Must1(os.Open("testdata/input.txt"))(t)
+{{- end}}
+{{- if .Long}}
+func Must{{.N}}[{{.Types}} any](
+	{{.Params}}, err error,
+) func(tb testing.TB) {{.Results}} {
+{{- else}}
+func Must{{.N}}[{{.Types}} any]({{.Params}}, err error) func(tb testing.TB) {{.Results}} {
+{{- end}}
+	return func(tb testing.TB) {{.Results}} {
+		if err != nil {
+			tb.Helper()
+			tb.Fatal(err)
+		}
+		return {{.Vals}}
+	}
+}
+{{end}}`))
+
+var fix = template.Must(template.New("fix").Parse(header + `package fix
+
+// maxArity is the highest N for which package check provides MustN, as
+// generated alongside it.
+const maxArity = {{len .}}
+`))
+
+func main() {
+	max := flag.Int("max", 6, "highest arity to generate")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	arities := make([]arity, *max)
+	for i := range arities {
+		arities[i] = arity(i + 1)
+	}
+	write("arity_gen.go", src, arities)
+	write("arity_gen_test.go", test, arities)
+	write("checktest/arity_gen.go", checktest, arities)
+	write("checkvet/internal/fix/arity_gen.go", fix, arities)
+}
+
+func write(name string, t *template.Template, arities []arity) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, arities); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(wrapComments(buf.Bytes()))
+	if err != nil {
+		log.Fatalf("%s: %v\n%s", name, err, buf.Bytes())
+	}
+	if err := os.WriteFile(name, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// wrapComments refills doc comment paragraphs with lines longer than 80
+// columns, leaving code blocks alone.
+func wrapComments(src []byte) []byte {
+	const width = 80
+	isText := func(line string) bool {
+		return strings.HasPrefix(line, "// ")
+	}
+	lines := strings.Split(string(src), "\n")
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		for isText(line) && len(line) > width {
+			cut := strings.LastIndex(line[:width+1], " ")
+			if cut <= len("//") {
+				break
+			}
+			out = append(out, line[:cut])
+			line = "// " + line[cut+1:]
+			if i+1 < len(lines) && isText(lines[i+1]) {
+				i++
+				line += " " + lines[i][len("// "):]
+			}
+		}
+		out = append(out, line)
+	}
+	return []byte(strings.Join(out, "\n"))
+}
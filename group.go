@@ -0,0 +1,125 @@
+package check
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Group runs tasks in their own goroutines, catching check failures in each
+// so that a Must call in a task fails the group instead of crashing the
+// process. The zero value is a valid Group that doesn't cancel anything on
+// failure.
+//
+//	g, ctx := check.WithContext(ctx)
+//	for _, url := range urls {
+//		url := url
+//		g.Go(func() {
+//			pages <- check.Must1(fetch(ctx, url))
+//		})
+//	}
+//	return g.Wait()
+type Group struct {
+	cancel context.CancelFunc
+	join   bool
+
+	wg    sync.WaitGroup
+	mu    sync.Mutex
+	errs  []error
+	panic *any
+}
+
+// WithContext returns a new Group and a context derived from ctx that is
+// canceled the first time a task fails or Wait returns, whichever comes
+// first.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// SetJoin controls what Wait returns when more than one task fails: the first
+// error if join is false, which is the default, or all of them if join is
+// true. It must not be called while tasks are running.
+func (g *Group) SetJoin(join bool) {
+	g.join = join
+}
+
+// Go calls work in a new goroutine. If work fails, Go transforms the error as
+// Catch would and records it, wrapped in a GoError, for Wait to return. Any
+// other panic is recorded and re-raised by Wait.
+func (g *Group) Go(work func(), transforms ...func(e error) error) {
+	var pc [1]uintptr
+	runtime.Callers(2, pc[:])
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				g.fail(nil, &r)
+			}
+		}()
+		if err := Catch(work, transforms...); err != nil {
+			g.fail(&GoError{err: err, pc: pc[0]}, nil)
+		}
+	}()
+}
+
+func (g *Group) fail(err error, r *any) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err != nil {
+		g.errs = append(g.errs, err)
+	}
+	if r != nil && g.panic == nil {
+		g.panic = r
+	}
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
+
+// Wait blocks until every task started with Go has returned. If a task
+// panicked with anything other than a check failure, Wait panics with the
+// same value. Otherwise it returns the first error, or all of them if SetJoin
+// was called with true, or nil if no task failed.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	if g.panic != nil {
+		panic(*g.panic)
+	}
+	switch {
+	case len(g.errs) == 0:
+		return nil
+	case len(g.errs) == 1 || !g.join:
+		return g.errs[0]
+	default:
+		return join(g.errs...)
+	}
+}
+
+// GoError is an error returned by a task started with Group.Go. It records
+// where the task was started.
+type GoError struct {
+	err error
+	pc  uintptr
+}
+
+// Error returns the task's error message, thus implementing the error
+// interface.
+func (e *GoError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the task's error as required by the errors packages.
+func (e *GoError) Unwrap() error {
+	return e.err
+}
+
+// Spawn returns the location of the call to Group.Go that started the task.
+func (e *GoError) Spawn() runtime.Frame {
+	frame, _ := runtime.CallersFrames([]uintptr{e.pc}).Next()
+	return frame
+}
@@ -0,0 +1,101 @@
+package check_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	var g check.Group
+	results := make([]int, 10)
+	for i := range results {
+		i := i
+		g.Go(func() {
+			results[i] = check.Must1(fmt.Sscan(fmt.Sprint(i), new(int)))
+		})
+	}
+	assert.NoError(t, g.Wait())
+	for _, n := range results {
+		assert.Equal(t, 1, n)
+	}
+}
+
+func TestGroupFailure(t *testing.T) {
+	t.Parallel()
+
+	var g check.Group
+	g.Go(func() {})
+	_, _, line, _ := runtime.Caller(0)
+	g.Go(func() { check.Fail(errOops) })
+	err := g.Wait()
+	assert.ErrorIs(t, err, errOops)
+	assert.EqualError(t, err, "oops")
+
+	var gerr *check.GoError
+	require.True(t, errors.As(err, &gerr))
+	frame := gerr.Spawn()
+	assert.Equal(t, "github.com/goeezi/check_test.TestGroupFailure", frame.Function)
+	assert.Equal(t, line+1, frame.Line)
+}
+
+func TestGroupTransform(t *testing.T) {
+	t.Parallel()
+
+	var g check.Group
+	g.Go(func() { check.Fail(errOops) }, func(e error) error {
+		return fmt.Errorf("task: %w", e)
+	})
+	g.Go(func() { check.Fail(errOops) }, func(error) error { return nil })
+	assert.EqualError(t, g.Wait(), "task: oops")
+}
+
+func TestGroupJoin(t *testing.T) {
+	t.Parallel()
+
+	var g check.Group
+	g.SetJoin(true)
+	g.Go(func() { check.Fail(errOops) })
+	g.Go(func() { check.Fail(errOther) })
+	err := g.Wait()
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+	assert.Contains(t, []string{"oops\nother", "other\noops"}, err.Error())
+}
+
+func TestGroupContext(t *testing.T) {
+	t.Parallel()
+
+	g, ctx := check.WithContext(context.Background())
+	g.Go(func() {
+		<-ctx.Done()
+		check.Must(ctx.Err())
+	})
+	g.Go(func() { check.Fail(errOops) })
+	assert.ErrorIs(t, g.Wait(), errOops)
+
+	g, ctx = check.WithContext(context.Background())
+	g.Go(func() {})
+	assert.NoError(t, g.Wait())
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestGroupPanic(t *testing.T) {
+	t.Parallel()
+
+	var g check.Group
+	g.Go(func() { check.Fail(errOops) })
+	g.Go(func() { panic(42) })
+	assert.PanicsWithValue(t, 42, func() {
+		_ = g.Wait()
+	})
+}
@@ -1,9 +1,9 @@
 package check
 
 import (
+	"errors"
 	"math"
-
-	"github.com/go-errors/errors"
+	"reflect"
 )
 
 // Handle, when deferred, recovers Error{err}. If any transforms are specified,
@@ -19,30 +19,47 @@ import (
 //			float64(Must1(strconv.Atoi(qty))), nil
 //	}
 func Handle(e *error, transforms ...func(e error) error) {
-	handle(recover(), math.MinInt, e, transforms...)
+	if r := recover(); r != nil {
+		handle(r, math.MinInt, e, transforms...)
+	}
 }
 
-// Wrap behaves like Handle, but additionally wraps any returned error in
-// "github.com/go-errors/errors".Error, which provides access to the stack
-// trace. Use skip to drop uninteresting stack frames.
+// Wrap behaves like Handle, but additionally wraps any returned error in a
+// StackError, which records the stack at the Must… or Fail… call that raised
+// the failure. Use skip to drop that many frames from the top of the stack,
+// such as those of helper functions. Errors that already record a stack aren't
+// wrapped again.
 func Wrap(e *error, skip int, transforms ...func(e error) error) {
-	handle(recover(), skip, e, transforms...)
+	if r := recover(); r != nil {
+		handle(r, skip, e, transforms...)
+	}
 }
 
 func handle(r any, skip int, e *error, transforms ...func(e error) error) {
 	if r != nil {
 		if wrapped, is := r.(Error); is {
-			err := wrapped.Unwrap()
+			orig := wrapped.err
+			err := orig
 			for _, transform := range transforms {
 				if err = transform(err); err == nil {
 					return
 				}
 			}
+			if wrapped.pc != 0 && !same(err, orig) && errors.Is(err, orig) {
+				// The transforms wrapped the error, so it can carry the
+				// location without changing an error they left alone.
+				if file, _, _ := Location(err); file == "" {
+					err = &located{err: err, pc: wrapped.pc}
+				}
+			}
 			if e == nil {
-				panic(Error{err})
+				panic(Error{err: err, pc: wrapped.pc})
 			}
 			if err != nil && skip != math.MinInt {
-				err = errors.Wrap(err, 4+skip)
+				var se *StackError
+				if !errors.As(err, &se) {
+					err = &StackError{err: err, frames: stack(skip)}
+				}
 			}
 			*e = err
 			return
@@ -50,3 +67,28 @@ func handle(r any, skip int, e *error, transforms ...func(e error) error) {
 		panic(r)
 	}
 }
+
+// HandleAll behaves like Handle, but also recovers panics that aren't check
+// failures, converting them to a PanicError before applying transforms.
+//
+//	func (s *Server) serve(w http.ResponseWriter, r *http.Request) (e error) {
+//		defer check.HandleAll(&e)
+//		…
+//	}
+func HandleAll(e *error, transforms ...func(e error) error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if _, is := r.(Error); !is {
+		r = Error{err: &PanicError{value: r, frames: stack(0)}}
+	}
+	handle(r, math.MinInt, e, transforms...)
+}
+
+// same reports whether a and b are the same error, without panicking if they
+// aren't comparable.
+func same(a, b error) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
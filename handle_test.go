@@ -3,9 +3,11 @@ package check_test
 import (
 	"errors"
 	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 
-	goerrors "github.com/go-errors/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -27,25 +29,46 @@ func TestHandle(t *testing.T) {
 	}(), "oops")
 }
 
+func TestHandleRetained(t *testing.T) {
+	t.Parallel()
+
+	// An Error recovered and passed on stays intact after Handle returns,
+	// and a zero Error is handled as a nil error.
+	var kept check.Error
+	err := check.Catch(func() {
+		defer func() {
+			kept = recover().(check.Error)
+			panic(kept)
+		}()
+		check.Fail(errOops)
+	})
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, kept, errOops)
+
+	assert.NoError(t, check.Catch(func() { panic(check.Error{}) }))
+}
+
 func TestWrap(t *testing.T) {
 	t.Parallel()
 
+	var line int
 	err := func() (e error) {
 		defer check.Wrap(&e, 1)
 		crash := func() {
 			check.Fail(errOops)
 		}
+		_, _, line, _ = runtime.Caller(0)
 		crash()
 		return
 	}()
 	assert.EqualError(t, err, "oops")
-	var werr *goerrors.Error
-	require.True(t, errors.As(err, &werr))
-	stk := werr.ErrorStack()
-	frame := werr.StackFrames()[0]
-	line, err := frame.SourceLine()
-	assert.NoError(t, err)
-	assert.Equal(t, "crash()", line, stk)
+	assert.ErrorIs(t, err, errOops)
+	var serr *check.StackError
+	require.True(t, errors.As(err, &serr))
+	frames := serr.Frames()
+	require.NotEmpty(t, frames)
+	assert.Equal(t, "github.com/goeezi/check_test.TestWrap.func1", frames[0].Function)
+	assert.Equal(t, line+1, frames[0].Line, "%+v", err)
 
 	assert.NoError(t, func() (e error) {
 		defer check.Handle(&e)
@@ -53,6 +76,52 @@ func TestWrap(t *testing.T) {
 	}(), "oops")
 }
 
+func TestWrapCallSite(t *testing.T) {
+	t.Parallel()
+
+	var line int
+	err := func() (e error) {
+		defer check.Wrap(&e, 0)
+		_, _, line, _ = runtime.Caller(0)
+		check.Must1(strconv.Atoi("x"))
+		return
+	}()
+	var serr *check.StackError
+	require.True(t, errors.As(err, &serr))
+	frame := serr.Frames()[0]
+	assert.Equal(t, "github.com/goeezi/check_test.TestWrapCallSite.func1", frame.Function)
+	assert.Equal(t, line+1, frame.Line)
+
+	var nerr *strconv.NumError
+	assert.True(t, errors.As(err, &nerr))
+
+	// Wrapping again keeps the original stack.
+	err2 := func() (e error) {
+		defer check.Wrap(&e, 0)
+		check.Fail(err)
+		return
+	}()
+	assert.Same(t, err, err2)
+}
+
+func TestStackErrorFormat(t *testing.T) {
+	t.Parallel()
+
+	err := func() (e error) {
+		defer check.Wrap(&e, 0)
+		check.Fail(errOops)
+		return
+	}()
+	assert.Equal(t, "oops", fmt.Sprintf("%v", err))
+	assert.Equal(t, "oops", fmt.Sprintf("%s", err))
+	assert.Equal(t, `"oops"`, fmt.Sprintf("%q", err))
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	require.GreaterOrEqual(t, len(lines), 3)
+	assert.Equal(t, "oops", lines[0])
+	assert.Equal(t, "github.com/goeezi/check_test.TestStackErrorFormat.func1", lines[1])
+	assert.Regexp(t, `^\t.*/handle_test\.go:\d+$`, lines[2])
+}
+
 func TestHandleTransform(t *testing.T) {
 	t.Parallel()
 
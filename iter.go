@@ -0,0 +1,68 @@
+//go:build go1.23
+
+package check
+
+import (
+	"iter"
+	"math"
+)
+
+// Values returns an iterator over the values yielded by seq, which fails with
+// the first error it yields, as Must does.
+//
+//	for line := range check.Values(lines(r)) {
+//		fmt.Println(line)
+//	}
+func Values[T any](seq iter.Seq2[T, error]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t, err := range seq {
+			if err != nil {
+				raise(err)
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// CatchSeq returns an iterator over the values yielded by seq, each paired
+// with a nil error, which catches failures raised by seq as Catch does and
+// yields the resulting error, if any, paired with the zero T as its final
+// element. Failures raised by the loop body that ranges over the iterator
+// aren't caught.
+//
+//	func lines(r io.Reader) iter.Seq2[string, error] {
+//		return check.CatchSeq(func(yield func(string) bool) {
+//			s := bufio.NewScanner(r)
+//			for s.Scan() && yield(s.Text()) {
+//			}
+//			check.Must(s.Err())
+//		})
+//	}
+func CatchSeq[T any](seq iter.Seq[T], transforms ...func(e error) error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var e error
+		consuming, done := false, false
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					if consuming {
+						panic(r)
+					}
+					handle(r, math.MinInt, &e, transforms...)
+				}
+			}()
+			seq(func(t T) bool {
+				consuming = true
+				done = !yield(t, nil)
+				consuming = false
+				return !done
+			})
+		}()
+		if e != nil && !done {
+			var zero T
+			yield(zero, e)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+//go:build go1.23
+
+package check_test
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goeezi/check"
+)
+
+// pairs yields the results of parsing each of ss.
+func pairs(ss ...string) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for _, s := range ss {
+			if !yield(strconv.Atoi(s)) {
+				return
+			}
+		}
+	}
+}
+
+// ints yields the result of parsing each of ss, failing on the first that
+// isn't an int.
+func ints(ss ...string) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, s := range ss {
+			if !yield(check.Must1(strconv.Atoi(s))) {
+				return
+			}
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	var got []int
+	err := check.Catch(func() {
+		for i := range check.Values(pairs("1", "2", "3")) {
+			got = append(got, i)
+		}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	got = nil
+	err = check.Catch(func() {
+		for i := range check.Values(pairs("1", "x", "3")) {
+			got = append(got, i)
+		}
+	})
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Equal(t, []int{1}, got)
+
+	got = nil
+	err = check.Catch(func() {
+		for i := range check.Values(pairs("1", "2", "x")) {
+			got = append(got, i)
+			break
+		}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, got)
+}
+
+func TestCatchSeq(t *testing.T) {
+	t.Parallel()
+
+	var got []int
+	var errs []error
+	for i, err := range check.CatchSeq(ints("1", "2")) {
+		got = append(got, i)
+		errs = append(errs, err)
+	}
+	assert.Equal(t, []int{1, 2}, got)
+	assert.Equal(t, []error{nil, nil}, errs)
+
+	got, errs = nil, nil
+	for i, err := range check.CatchSeq(ints("1", "x", "3"), check.Prefix("parsing")) {
+		got = append(got, i)
+		errs = append(errs, err)
+	}
+	assert.Equal(t, []int{1, 0}, got)
+	if assert.Len(t, errs, 2) {
+		assert.NoError(t, errs[0])
+		assert.ErrorIs(t, errs[1], strconv.ErrSyntax)
+		assert.EqualError(t, errs[1], `parsing: strconv.Atoi: parsing "x": invalid syntax`)
+	}
+}
+
+func TestCatchSeqBreak(t *testing.T) {
+	t.Parallel()
+
+	var got []int
+	for i, err := range check.CatchSeq(ints("1", "2", "x")) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, i)
+		if i == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestCatchSeqConsumerFailure(t *testing.T) {
+	t.Parallel()
+
+	// Failures in the loop body belong to the consumer.
+	err := check.Catch(func() {
+		for range check.CatchSeq(ints("1", "2")) {
+			check.Fail(errOops)
+		}
+	})
+	assert.Equal(t, errOops, err)
+}
+
+func TestValuesCatchSeq(t *testing.T) {
+	t.Parallel()
+
+	var got []int
+	err := check.Catch(func() {
+		for i := range check.Values(check.CatchSeq(ints("4", "x"))) {
+			got = append(got, i)
+		}
+	})
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(err, &numErr))
+	assert.Equal(t, []int{4}, got)
+}
@@ -0,0 +1,98 @@
+package check
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Kind classifies failures for machine consumption, such as mapping them to
+// HTTP or gRPC status codes or labelling metrics. The zero Kind is Unknown.
+type Kind int
+
+const (
+	Unknown Kind = iota
+	Invalid
+	Unauthenticated
+	PermissionDenied
+	NotFound
+	Conflict
+	Unavailable
+	Internal
+)
+
+var kindNames = [...]string{
+	Unknown:          "Unknown",
+	Invalid:          "Invalid",
+	Unauthenticated:  "Unauthenticated",
+	PermissionDenied: "PermissionDenied",
+	NotFound:         "NotFound",
+	Conflict:         "Conflict",
+	Unavailable:      "Unavailable",
+	Internal:         "Internal",
+}
+
+// String returns the name of k.
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Failk panics Error{err}, where err has the message fmt.Sprintf(format,
+// args...) and is of the given kind. As with fmt.Errorf, format may use %w.
+//
+//	check.Failk(check.NotFound, "user %d", id)
+func Failk(kind Kind, format string, args ...any) {
+	raiseNew(&kindError{kind: kind, err: fmt.Errorf(format, args...)})
+}
+
+// Tag returns a transform that marks errors as being of the given kind,
+// overriding any kind already in their chain.
+//
+//	defer check.Handle(&e, check.Tag(check.Unavailable))
+func Tag(kind Kind) func(e error) error {
+	return func(e error) error {
+		return &kindError{kind: kind, err: e}
+	}
+}
+
+// KindOf returns the kind of err: that of the first error in its chain, in
+// depth-first order, with a Kind method that doesn't return Unknown, such as
+// those created by Failk and Tag. It returns Unknown if there is none.
+func KindOf(err error) Kind {
+	if k, ok := err.(interface{ Kind() Kind }); ok {
+		if kind := k.Kind(); kind != Unknown {
+			return kind
+		}
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return KindOf(e.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if kind := KindOf(err); kind != Unknown {
+				return kind
+			}
+		}
+	}
+	return Unknown
+}
+
+// kindError is an error of a particular kind.
+type kindError struct {
+	kind Kind
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+func (e *kindError) Kind() Kind {
+	return e.kind
+}
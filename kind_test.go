@@ -0,0 +1,76 @@
+package check_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goeezi/check"
+)
+
+func TestKindString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Unknown", check.Unknown.String())
+	assert.Equal(t, "NotFound", check.NotFound.String())
+	assert.Equal(t, "Internal", check.Internal.String())
+	assert.Equal(t, "Kind(42)", check.Kind(42).String())
+}
+
+func TestFailk(t *testing.T) {
+	t.Parallel()
+
+	err := check.Catch(func() { check.Failk(check.NotFound, "user %d", 42) })
+	assert.EqualError(t, err, "user 42")
+	assert.Equal(t, check.NotFound, check.KindOf(err))
+
+	err = check.Catch(func() { check.Failk(check.Invalid, "parsing: %w", errOops) })
+	assert.EqualError(t, err, "parsing: oops")
+	assert.ErrorIs(t, err, errOops)
+	assert.Equal(t, check.Invalid, check.KindOf(err))
+}
+
+func TestTag(t *testing.T) {
+	t.Parallel()
+
+	err := check.Catch(func() { check.Fail(errOops) }, check.Tag(check.Unavailable))
+	assert.EqualError(t, err, "oops")
+	assert.ErrorIs(t, err, errOops)
+	assert.Equal(t, check.Unavailable, check.KindOf(err))
+
+	// The outermost kind wins.
+	err = check.Catch(func() {
+		check.Failk(check.NotFound, "user")
+	}, check.Tag(check.Internal))
+	assert.Equal(t, check.Internal, check.KindOf(err))
+
+	assert.Equal(t, check.Unknown, check.KindOf(errOops))
+	assert.Equal(t, check.Unknown, check.KindOf(nil))
+}
+
+func TestKindSurvives(t *testing.T) {
+	t.Parallel()
+
+	err := func() (e error) {
+		defer check.Wrap(&e, 0, check.Prefix("outer"))
+		check.Failk(check.Conflict, "version %d", 3)
+		return nil
+	}()
+	assert.EqualError(t, err, "outer: version 3")
+	assert.Equal(t, check.Conflict, check.KindOf(err))
+
+	err = check.Catch(func() {
+		check.FailAll(errOops, check.Catch(func() {
+			check.Failk(check.PermissionDenied, "denied")
+		}))
+	})
+	assert.Equal(t, check.PermissionDenied, check.KindOf(err))
+
+	err = check.Catch(func() {
+		check.Fail(fmt.Errorf("loading: %w", check.Catch(func() {
+			check.Failk(check.Unauthenticated, "no token")
+		})))
+	}, check.Prefix("request"))
+	assert.Equal(t, check.Unauthenticated, check.KindOf(err))
+}
@@ -0,0 +1,144 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// LogOption configures Log.
+type LogOption func(*failureLogger)
+
+// LogStack includes the stack of the failing call in each record, as a
+// "stack" attribute listing one "function file:line" per frame.
+func LogStack() LogOption {
+	return func(l *failureLogger) {
+		l.stack = true
+	}
+}
+
+// LogEvery limits logging to one record per call site per interval. The next
+// record from a call site reports how many failures were dropped in a
+// "suppressed" attribute.
+func LogEvery(interval time.Duration) LogOption {
+	return func(l *failureLogger) {
+		l.interval = interval
+	}
+}
+
+// Log returns a transform that logs each error to logger at the given level
+// and returns it unchanged. Records have the message "check failure", the
+// error, the types along its chain and, when the transform is called by
+// Handle, Catch or a similar function while recovering a failure, the Must…
+// or Fail… call that raised it as the record's source, which handlers report
+// if configured to, as with slog.HandlerOptions.AddSource.
+//
+//	defer check.Handle(&e, check.Log(slog.Default(), slog.LevelWarn, check.LogEvery(time.Minute)))
+func Log(logger *slog.Logger, level slog.Level, opts ...LogOption) func(e error) error {
+	l := &failureLogger{logger: logger, level: level, sites: map[string]*logSite{}}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l.log
+}
+
+type failureLogger struct {
+	logger   *slog.Logger
+	level    slog.Level
+	stack    bool
+	interval time.Duration
+
+	mu    sync.Mutex
+	sites map[string]*logSite
+}
+
+// logSite tracks rate limiting for a call site.
+type logSite struct {
+	last       time.Time
+	suppressed int
+}
+
+func (l *failureLogger) log(e error) error {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, l.level) {
+		return e
+	}
+	var frames []runtime.Frame
+	var frame runtime.Frame
+	if l.stack {
+		if frames = stack(0); len(frames) > 0 {
+			frame = frames[0]
+		}
+	} else {
+		frame, _ = raiser()
+	}
+	// The record's PC makes the handler report the raising call as its
+	// source, if it is configured to add one. Like the PCs returned by
+	// runtime.Callers, it is the return address, one past the frame's PC.
+	var pc uintptr
+	if frame.PC != 0 {
+		pc = frame.PC + 1
+	}
+	r := slog.NewRecord(time.Now(), l.level, "check failure", pc)
+	r.AddAttrs(slog.Any("error", e), slog.Any("chain", chain(e)))
+	if frame.PC != 0 && l.interval > 0 {
+		suppressed, ok := l.allow(fmt.Sprintf("%s:%d", frame.File, frame.Line))
+		if !ok {
+			return e
+		}
+		if suppressed > 0 {
+			r.AddAttrs(slog.Int("suppressed", suppressed))
+		}
+	}
+	if l.stack {
+		lines := make([]string, len(frames))
+		for i, f := range frames {
+			lines[i] = fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line)
+		}
+		r.AddAttrs(slog.Any("stack", lines))
+	}
+	_ = l.logger.Handler().Handle(ctx, r)
+	return e
+}
+
+// allow reports whether the call site may log now, and if so, how many
+// records were suppressed since it last did.
+func (l *failureLogger) allow(site string) (suppressed int, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	s := l.sites[site]
+	if s == nil {
+		s = &logSite{}
+		l.sites[site] = s
+	} else if now.Sub(s.last) < l.interval {
+		s.suppressed++
+		return 0, false
+	}
+	suppressed, s.suppressed, s.last = s.suppressed, 0, now
+	return suppressed, true
+}
+
+// chain returns the types of the errors in e's tree, in depth-first order.
+func chain(e error) []string {
+	var types []string
+	var walk func(err error)
+	walk = func(err error) {
+		types = append(types, fmt.Sprintf("%T", err))
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			if next := u.Unwrap(); next != nil {
+				walk(next)
+			}
+		case interface{ Unwrap() []error }:
+			for _, next := range u.Unwrap() {
+				walk(next)
+			}
+		}
+	}
+	walk(e)
+	return types
+}
@@ -0,0 +1,126 @@
+package check_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+
+// records decodes the JSON log records in buf.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var recs []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		recs = append(recs, rec)
+	}
+	return recs
+}
+
+func TestLog(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true}))
+
+	var line int
+	err := func() (e error) {
+		defer check.Handle(&e, check.Prefix("loading"), check.Log(logger, slog.LevelError))
+		_, _, line, _ = runtime.Caller(0)
+		check.Fail(errOops)
+		return nil
+	}()
+	assert.EqualError(t, err, "loading: oops")
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 1)
+	rec := recs[0]
+	assert.Equal(t, "ERROR", rec["level"])
+	assert.Equal(t, "check failure", rec["msg"])
+	assert.Equal(t, "loading: oops", rec["error"])
+	assert.Equal(t, []any{"*fmt.wrapError", "*errors.errorString"}, rec["chain"])
+	source := rec["source"].(map[string]any)
+	assert.Equal(t, "github.com/goeezi/check_test.TestLog.func1", source["function"])
+	assert.Equal(t, float64(line+1), source["line"])
+	assert.NotContains(t, rec, "stack")
+	assert.Equal(t, 1, strings.Count(buf.String(), `"source"`))
+}
+
+func TestLogStack(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	assert.Error(t, check.Catch(func() {
+		check.Fail(errOops)
+	}, check.Log(logger, slog.LevelInfo, check.LogStack())))
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 1)
+	stack := recs[0]["stack"].([]any)
+	require.NotEmpty(t, stack)
+	assert.True(t, strings.HasPrefix(stack[0].(string), "github.com/goeezi/check_test.TestLogStack.func1 "), stack[0])
+}
+
+func TestLogLevel(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	assert.Error(t, check.Catch(func() { check.Fail(errOops) }, check.Log(logger, slog.LevelInfo)))
+	assert.Empty(t, buf.String())
+}
+
+func TestLogEvery(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	transform := check.Log(logger, slog.LevelError, check.LogEvery(time.Hour))
+	for i := 0; i < 5; i++ {
+		err := check.Catch(func() { check.Fail(fmt.Errorf("hot %d", i)) }, transform)
+		assert.EqualError(t, err, fmt.Sprintf("hot %d", i))
+	}
+	assert.Error(t, check.Catch(func() { check.Fail(errOops) }, transform))
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 2)
+	assert.Equal(t, "hot 0", recs[0]["error"])
+	assert.Equal(t, "oops", recs[1]["error"])
+	assert.NotContains(t, recs[0], "suppressed")
+}
+
+func TestLogEverySuppressed(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	transform := check.Log(logger, slog.LevelError, check.LogEvery(200*time.Millisecond))
+	fail := func() {
+		assert.Error(t, check.Catch(func() { check.Fail(errOops) }, transform))
+	}
+	fail()
+	fail()
+	fail()
+	time.Sleep(250 * time.Millisecond)
+	fail()
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 2)
+	assert.Equal(t, float64(2), recs[1]["suppressed"])
+}
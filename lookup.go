@@ -0,0 +1,109 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrNotFound is matched by the failures of MustOK, Key and Index.
+	ErrNotFound = errors.New("not found")
+
+	// ErrBadType is matched by the failures of Cast.
+	ErrBadType = errors.New("bad type")
+)
+
+// KeyError reports a key missing from a map.
+type KeyError struct {
+	Key any
+}
+
+// Error returns a message naming the key, thus implementing the error
+// interface.
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("key %#v not found", e.Key)
+}
+
+// Unwrap returns ErrNotFound.
+func (e *KeyError) Unwrap() error {
+	return ErrNotFound
+}
+
+// IndexError reports an index out of a slice's range.
+type IndexError struct {
+	Index, Len int
+}
+
+// Error returns a message naming the index and length, thus implementing the
+// error interface.
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d out of range [0:%d]", e.Index, e.Len)
+}
+
+// Unwrap returns ErrNotFound.
+func (e *IndexError) Unwrap() error {
+	return ErrNotFound
+}
+
+// TypeError reports a value that doesn't have the expected type.
+type TypeError struct {
+	Value any
+	Want  reflect.Type
+}
+
+// Error returns a message naming the actual and expected types, thus
+// implementing the error interface.
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("bad type %T, want %v", e.Value, e.Want)
+}
+
+// Unwrap returns ErrBadType.
+func (e *TypeError) Unwrap() error {
+	return ErrBadType
+}
+
+// MustOK returns v if ok is true, otherwise it calls panic(Error{ErrNotFound}).
+//
+//	v := check.MustOK(cache.Load(key))
+func MustOK[T any](v T, ok bool) T {
+	if !ok {
+		raise(ErrNotFound)
+	}
+	return v
+}
+
+// Cast returns x.(T) if x holds a T, otherwise it calls panic(Error{err}),
+// where err is a TypeError.
+//
+//	name := check.Cast[string](claims["name"])
+func Cast[T any](x any) T {
+	t, ok := x.(T)
+	if !ok {
+		raise(&TypeError{Value: x, Want: reflect.TypeOf((*T)(nil)).Elem()})
+	}
+	return t
+}
+
+// Key returns m[k] if m contains k, otherwise it calls panic(Error{err}),
+// where err is a KeyError.
+//
+//	user := check.Key(usersByID, id)
+func Key[M ~map[K]V, K comparable, V any](m M, k K) V {
+	v, ok := m[k]
+	if !ok {
+		raise(&KeyError{Key: k})
+	}
+	return v
+}
+
+// Index returns s[i] if i is in range, otherwise it calls panic(Error{err}),
+// where err is an IndexError.
+//
+//	cmd := check.Index(os.Args, 1)
+func Index[S ~[]E, E any](s S, i int) E {
+	if i < 0 || i >= len(s) {
+		raise(&IndexError{Index: i, Len: len(s)})
+	}
+	return s[i]
+}
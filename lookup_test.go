@@ -0,0 +1,86 @@
+package check_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+
+func TestMustOK(t *testing.T) {
+	t.Parallel()
+
+	lookup := func(k string) (int, bool) {
+		v, ok := map[string]int{"a": 1}[k]
+		return v, ok
+	}
+	v, err := check.Catch1(func() int { return check.MustOK(lookup("a")) })
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	_, err = check.Catch1(func() int { return check.MustOK(lookup("b")) })
+	assert.Equal(t, check.ErrNotFound, err)
+}
+
+func TestCast(t *testing.T) {
+	t.Parallel()
+
+	s, err := check.Catch1(func() string { return check.Cast[string](any("x")) })
+	require.NoError(t, err)
+	assert.Equal(t, "x", s)
+
+	e, err := check.Catch1(func() error { return check.Cast[error](errOops) })
+	require.NoError(t, err)
+	assert.Equal(t, errOops, e)
+
+	_, err = check.Catch1(func() string { return check.Cast[string](42) })
+	assert.EqualError(t, err, "bad type int, want string")
+	assert.ErrorIs(t, err, check.ErrBadType)
+	var terr *check.TypeError
+	require.True(t, errors.As(err, &terr))
+	assert.Equal(t, 42, terr.Value)
+	assert.Equal(t, reflect.TypeOf(""), terr.Want)
+
+	_, err = check.Catch1(func() fmt.Stringer { return check.Cast[fmt.Stringer](nil) })
+	assert.EqualError(t, err, "bad type <nil>, want fmt.Stringer")
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	type ages map[string]int
+	m := ages{"alice": 42}
+	v, err := check.Catch1(func() int { return check.Key(m, "alice") })
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	_, err = check.Catch1(func() int { return check.Key(m, "bob") })
+	assert.EqualError(t, err, `key "bob" not found`)
+	assert.ErrorIs(t, err, check.ErrNotFound)
+	var kerr *check.KeyError
+	require.True(t, errors.As(err, &kerr))
+	assert.Equal(t, "bob", kerr.Key)
+}
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+
+	s := []string{"a", "b"}
+	v, err := check.Catch1(func() string { return check.Index(s, 1) })
+	require.NoError(t, err)
+	assert.Equal(t, "b", v)
+
+	for _, i := range []int{-1, 2} {
+		_, err = check.Catch1(func() string { return check.Index(s, i) })
+		assert.EqualError(t, err, fmt.Sprintf("index %d out of range [0:2]", i))
+		assert.ErrorIs(t, err, check.ErrNotFound)
+		var ierr *check.IndexError
+		require.True(t, errors.As(err, &ierr))
+		assert.Equal(t, check.IndexError{Index: i, Len: 2}, *ierr)
+	}
+}
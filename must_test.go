@@ -147,3 +147,66 @@ func TestMust4(t *testing.T) {
 	}()
 	assert.EqualError(t, err, "cannot analyze empty input", "%v %v %v %v", o, h, l, c)
 }
+
+func TestMustf(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, check.Catch(func() {
+		check.Mustf(nil)("removing %s", "x")
+	}))
+
+	err := check.Catch(func() {
+		check.Mustf(errOops)("removing %s", "x")
+	})
+	assert.EqualError(t, err, "removing x: oops")
+	assert.ErrorIs(t, err, errOops)
+}
+
+func TestMust1f(t *testing.T) {
+	t.Parallel()
+
+	a, err := check.Catch1(func() int {
+		return check.Must1f(strconv.Atoi("42"))("parsing %q", "42")
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 42, a)
+	}
+
+	_, err = check.Catch1(func() int {
+		return check.Must1f(strconv.Atoi("forty-two"))("parsing answer")
+	})
+	assert.EqualError(t, err, "parsing answer: strconv.Atoi: parsing \"forty-two\": invalid syntax")
+	var nerr *strconv.NumError
+	assert.True(t, errors.As(err, &nerr))
+}
+
+func TestMustNf(t *testing.T) {
+	t.Parallel()
+
+	q, r, err := check.Catch2(func() (float64, float64) {
+		return check.Must2f(divmod(7, 2))("dividing")
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []float64{3, 1}, []float64{q, r})
+	}
+	_, _, err = check.Catch2(func() (float64, float64) {
+		return check.Must2f(divmod(0, 0))("dividing %v by %v", 0, 0)
+	})
+	assert.EqualError(t, err, "dividing 0 by 0: cannot divmod(0, 0)")
+
+	_, _, _, err = check.Catch3(func() (float64, float64, float64) {
+		return check.Must3f(muldivmod(0, 0))("step %d", 3)
+	})
+	assert.EqualError(t, err, "step 3: cannot muldivmod(0, 0)")
+
+	o, h, l, c, err := check.Catch4(func() (o, h, l, c float64) {
+		return check.Must4f(analyzeTrades(3, 1, 4))("analyzing")
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []float64{3, 4, 1, 4}, []float64{o, h, l, c})
+	}
+	_, _, _, _, err = check.Catch4(func() (o, h, l, c float64) {
+		return check.Must4f(analyzeTrades())("analyzing")
+	})
+	assert.EqualError(t, err, "analyzing: cannot analyze empty input")
+}
@@ -0,0 +1,63 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// PanicError is the error to which HandleAll and CatchAll convert panics that
+// aren't check failures.
+type PanicError struct {
+	value  any
+	frames []runtime.Frame
+}
+
+// Value returns the value passed to panic.
+func (e *PanicError) Value() any {
+	return e.value
+}
+
+// RuntimeError returns the runtime error that caused the panic, such as an
+// index out of range, or nil if the panic was raised by a call to panic.
+func (e *PanicError) RuntimeError() runtime.Error {
+	err, _ := e.value.(runtime.Error)
+	return err
+}
+
+// Frames returns the stack, innermost call first, starting where the panic
+// was raised.
+func (e *PanicError) Frames() []runtime.Frame {
+	return e.frames
+}
+
+// Error returns "panic: " followed by the panic value, thus implementing the
+// error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// Unwrap returns the panic value if it is an error, otherwise nil.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.value.(error)
+	return err
+}
+
+// Format implements fmt.Formatter in the same way as StackError.
+func (e *PanicError) Format(s fmt.State, verb rune) {
+	formatStack(s, verb, e.Error(), e.Error(), e.frames)
+}
+
+// Repanic is the error counterpart of Pass. If err is a PanicError, Repanic
+// panics with the original value, otherwise it returns err. As a transform,
+// it restores Handle's strict behaviour for panics recovered by HandleAll or
+// CatchAll further down the stack.
+//
+//	check.Must(check.Repanic(lib.Do()))
+func Repanic(err error) error {
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		panic(pe.value)
+	}
+	return err
+}
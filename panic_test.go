@@ -0,0 +1,111 @@
+package check_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+
+func TestHandleAll(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, func() (e error) {
+		defer check.HandleAll(&e)
+		check.Fail(errOops)
+		return
+	}(), "oops")
+
+	var line int
+	err := func() (e error) {
+		defer check.HandleAll(&e)
+		var m map[string]int
+		_, _, line, _ = runtime.Caller(0)
+		m["x"] = 1
+		return
+	}()
+	assert.EqualError(t, err, "panic: assignment to entry in nil map")
+	var pe *check.PanicError
+	require.True(t, errors.As(err, &pe))
+	assert.NotNil(t, pe.RuntimeError())
+	var rerr runtime.Error
+	assert.True(t, errors.As(err, &rerr))
+	frame := pe.Frames()[0]
+	assert.Equal(t, "github.com/goeezi/check_test.TestHandleAll.func2", frame.Function)
+	assert.Equal(t, line+1, frame.Line)
+
+	assert.NoError(t, func() (e error) {
+		defer check.HandleAll(&e)
+		return
+	}())
+}
+
+func TestHandleAllTransform(t *testing.T) {
+	t.Parallel()
+
+	err := func() (e error) {
+		defer check.HandleAll(&e, check.Prefix("serving"))
+		panic(42)
+	}()
+	assert.EqualError(t, err, "serving: panic: 42")
+	var pe *check.PanicError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 42, pe.Value())
+	assert.Nil(t, pe.RuntimeError())
+	assert.Nil(t, pe.Unwrap())
+
+	assert.PanicsWithError(t, "panic: 42", func() {
+		defer check.HandleAll(nil)
+		panic(42)
+	})
+}
+
+func TestCatchAll(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, check.CatchAll(func() {}))
+	assert.ErrorIs(t, check.CatchAll(func() { check.Fail(errOops) }), errOops)
+
+	err := check.CatchAll(func() {
+		var s []int
+		_ = s[len(errOops.Error())]
+	})
+	var pe *check.PanicError
+	require.True(t, errors.As(err, &pe))
+	assert.Contains(t, pe.Error(), "index out of range")
+
+	err = check.CatchAll(func() { panic(errOops) })
+	assert.EqualError(t, err, "panic: oops")
+	assert.ErrorIs(t, err, errOops)
+}
+
+func TestPanicErrorFormat(t *testing.T) {
+	t.Parallel()
+
+	err := check.CatchAll(func() { panic("boom") })
+	assert.Equal(t, "panic: boom", fmt.Sprintf("%v", err))
+	assert.Equal(t, `"panic: boom"`, fmt.Sprintf("%q", err))
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	require.GreaterOrEqual(t, len(lines), 3)
+	assert.Equal(t, "panic: boom", lines[0])
+	assert.Equal(t, "github.com/goeezi/check_test.TestPanicErrorFormat.func1", lines[1])
+}
+
+func TestRepanic(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, check.Repanic(nil))
+	assert.Equal(t, errOops, check.Repanic(errOops))
+	assert.PanicsWithValue(t, 42, func() {
+		_ = check.Repanic(check.CatchAll(func() { panic(42) }))
+	})
+	assert.PanicsWithValue(t, 42, func() {
+		_ = check.CatchAll(func() { panic(42) }, check.Repanic)
+	})
+}
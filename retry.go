@@ -0,0 +1,181 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Policy controls how Retry and Retry1 retry failing work. The zero value
+// makes up to three attempts, waiting 100ms then 200ms between them, and
+// retries errors reported as retryable or temporary.
+type Policy struct {
+	// MaxAttempts limits the number of calls to work. Zero means 3 and a
+	// negative value means no limit.
+	MaxAttempts int
+
+	// MaxElapsed, if not zero, stops retrying once waiting for the next
+	// attempt would take longer than MaxElapsed since the first.
+	MaxElapsed time.Duration
+
+	// Initial is the wait before the second attempt. Zero means 100ms.
+	Initial time.Duration
+
+	// Multiplier scales the wait after each attempt. Zero means 2.
+	Multiplier float64
+
+	// Max caps the wait between attempts. Zero means an hour.
+	Max time.Duration
+
+	// Jitter randomizes each wait by up to ±Jitter of its length, spreading
+	// out retries from concurrent callers. It should be between 0 and 1.
+	Jitter float64
+
+	// RetryOn lists errors that are retried if errors.Is matches them.
+	RetryOn []error
+
+	// Retryable, if not nil, reports whether an error is retried, replacing
+	// the default of checking RetryOn and for errors in the chain with a
+	// Retryable() bool or Temporary() bool method that returns true.
+	Retryable func(err error) bool
+
+	// Clock, if not nil, replaces the real clock.
+	Clock Clock
+}
+
+// Clock tells the time and waits for Retry. Tests can supply a fake to run
+// without delay.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RetryError is returned by Retry and Retry1 when work fails more than once.
+// It unwraps to the error from every attempt.
+type RetryError struct {
+	errs []error
+}
+
+// Error reports the number of attempts and the last error, thus implementing
+// the error interface.
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("after %d attempts: %v", len(e.errs), e.errs[len(e.errs)-1])
+}
+
+// Unwrap returns the error from every attempt, in order, as required by the
+// errors packages.
+func (e *RetryError) Unwrap() []error {
+	return e.errs
+}
+
+// Retry calls work, catching failures as CatchCtx does, until it succeeds, it
+// fails with an error that p doesn't retry, or p's budget is exhausted,
+// waiting between attempts with exponential backoff. If work only ran once,
+// its error is returned unchanged. Otherwise the result is a RetryError.
+//
+//	err := check.Retry(ctx, check.Policy{RetryOn: []error{ErrBusy}}, func() {
+//		resp = check.Must1(client.Do(req))
+//	})
+func Retry(ctx context.Context, p Policy, work func()) error {
+	_, err := Retry1(ctx, p, func() struct{} {
+		work()
+		return struct{}{}
+	})
+	return err
+}
+
+// Retry1 behaves like Retry, but returns the result of the successful call to
+// work.
+//
+//	resp, err := check.Retry1(ctx, check.Policy{}, func() *http.Response {
+//		return check.Must1(client.Do(req))
+//	})
+func Retry1[T any](ctx context.Context, p Policy, work func() T) (T, error) {
+	clock := p.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+	maxAttempts := p.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = 3
+	}
+	delay := p.Initial
+	if delay == 0 {
+		delay = 100 * time.Millisecond
+	}
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	maxDelay := p.Max
+	if maxDelay == 0 {
+		maxDelay = time.Hour
+	}
+
+	start := clock.Now()
+	var errs []error
+loop:
+	for attempt := 1; ; attempt++ {
+		t, err := CatchCtx1(ctx, work)
+		if err == nil {
+			return t, nil
+		}
+		errs = append(errs, err)
+		var ce *ContextError
+		if errors.As(err, &ce) || !p.retryable(err) || attempt == maxAttempts {
+			break
+		}
+
+		wait := min(delay, maxDelay)
+		if p.Jitter != 0 {
+			wait += time.Duration(p.Jitter * (2*rand.Float64() - 1) * float64(wait))
+		}
+		if p.MaxElapsed != 0 && clock.Now().Add(wait).Sub(start) > p.MaxElapsed {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			errs = append(errs, newContextError(ctx, nil))
+			break loop
+		case <-clock.After(wait):
+		}
+		// Clamp before converting back from floating point, so that the delay
+		// can't overflow however many attempts are made.
+		if next := float64(delay) * multiplier; next < float64(maxDelay) {
+			delay = time.Duration(next)
+		} else {
+			delay = maxDelay
+		}
+	}
+
+	var t T
+	if len(errs) == 1 {
+		return t, errs[0]
+	}
+	return t, &RetryError{errs: errs}
+}
+
+// retryable reports whether p retries err.
+func (p *Policy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	for _, target := range p.RetryOn {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	var r interface{ Retryable() bool }
+	if errors.As(err, &r) && r.Retryable() {
+		return true
+	}
+	var t interface{ Temporary() bool }
+	return errors.As(err, &t) && t.Temporary()
+}
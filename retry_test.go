@@ -0,0 +1,168 @@
+package check_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goeezi/check"
+)
+
+// fakeClock advances instantly, recording each wait.
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+type tempError struct{}
+
+func (tempError) Error() string   { return "try again" }
+func (tempError) Temporary() bool { return true }
+
+// flaky returns work that fails with errs in turn, then succeeds.
+func flaky(calls *int, errs ...error) func() int {
+	return func() int {
+		*calls++
+		if *calls <= len(errs) {
+			check.Fail(errs[*calls-1])
+		}
+		return *calls
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{}
+	calls := 0
+	n, err := check.Retry1(context.Background(), check.Policy{Clock: clock},
+		flaky(&calls, tempError{}, tempError{}))
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, clock.waits)
+
+	calls = 0
+	err = check.Retry(context.Background(), check.Policy{Clock: &fakeClock{}}, func() {
+		flaky(&calls, errOops)()
+	})
+	assert.Equal(t, errOops, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{}
+	calls := 0
+	p := check.Policy{
+		MaxAttempts: 4,
+		Initial:     time.Second,
+		Multiplier:  3,
+		Max:         5 * time.Second,
+		RetryOn:     []error{errOops, errOther},
+		Clock:       clock,
+	}
+	_, err := check.Retry1(context.Background(), p, flaky(&calls, errOops, errOther, errOops, errOther, errOops))
+	assert.Equal(t, 4, calls)
+	assert.EqualError(t, err, "after 4 attempts: other")
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+	var rerr *check.RetryError
+	require.True(t, errors.As(err, &rerr))
+	assert.Equal(t, []error{errOops, errOther, errOops, errOther}, rerr.Unwrap())
+	assert.Equal(t, []time.Duration{time.Second, 3 * time.Second, 5 * time.Second}, clock.waits)
+}
+
+func TestRetryMaxElapsed(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{}
+	calls := 0
+	p := check.Policy{
+		MaxAttempts: -1,
+		MaxElapsed:  time.Second,
+		Clock:       clock,
+		Retryable:   func(error) bool { return true },
+	}
+	_, err := check.Retry1(context.Background(), p, flaky(&calls, errOops, errOops, errOops, errOops, errOops))
+	assert.EqualError(t, err, "after 4 attempts: oops")
+	ms := time.Millisecond
+	assert.Equal(t, []time.Duration{100 * ms, 200 * ms, 400 * ms}, clock.waits)
+}
+
+func TestRetryJitter(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{}
+	calls := 0
+	p := check.Policy{MaxAttempts: 10, Jitter: 0.5, Initial: time.Second, Multiplier: 1, Clock: clock}
+	_, err := check.Retry1(context.Background(), p, flaky(&calls, tempError{}, tempError{}, tempError{}, tempError{}))
+	require.NoError(t, err)
+	for _, wait := range clock.waits {
+		assert.GreaterOrEqual(t, wait, 500*time.Millisecond)
+		assert.LessOrEqual(t, wait, 1500*time.Millisecond)
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := check.Retry(ctx, check.Policy{MaxAttempts: -1, Clock: &fakeClock{}}, func() {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		check.Fail(tempError{})
+	})
+	assert.Equal(t, 2, calls)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, tempError{})
+}
+
+func TestRetryMaxDelay(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range []check.Policy{
+		{Multiplier: 1e10},
+		{Multiplier: 1e10, Max: time.Minute},
+		{Multiplier: 1e10, Max: math.MaxInt64},
+	} {
+		clock := &fakeClock{}
+		p.MaxAttempts, p.Clock = 80, clock
+		p.Retryable = func(error) bool { return true }
+		_, err := check.Retry1(context.Background(), p, func() int {
+			check.Fail(errOops)
+			return 0
+		})
+		require.Error(t, err)
+		want := p.Max
+		if want == 0 {
+			want = time.Hour
+		}
+		require.Len(t, clock.waits, 79)
+		for _, wait := range clock.waits {
+			assert.GreaterOrEqual(t, wait, 100*time.Millisecond)
+			assert.LessOrEqual(t, wait, want)
+		}
+		assert.Equal(t, want, clock.waits[78])
+	}
+}
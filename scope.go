@@ -0,0 +1,74 @@
+package check
+
+import "math"
+
+// Scope collects cleanups for a function that performs several steps, each
+// of which must be undone if a later step fails. The zero value is an empty
+// Scope.
+//
+//	func setup(dir string) (_ *Server, e error) {
+//		var s check.Scope
+//		defer s.Handle(&e)
+//		check.Must(os.Mkdir(dir, 0o700))
+//		s.OnFailure(func() error { return os.RemoveAll(dir) })
+//		f := check.Must1(os.Create(filepath.Join(dir, "log")))
+//		s.OnFailure(f.Close)
+//		return check.Must1(start(f)), nil
+//	}
+type Scope struct {
+	cleanups []cleanup
+}
+
+type cleanup struct {
+	f      func() error
+	always bool
+}
+
+// OnFailure registers undo to run if the function fails.
+func (s *Scope) OnFailure(undo func() error) {
+	s.cleanups = append(s.cleanups, cleanup{f: undo})
+}
+
+// Always registers f to run whether or not the function fails.
+func (s *Scope) Always(f func() error) {
+	s.cleanups = append(s.cleanups, cleanup{f: f, always: true})
+}
+
+// Handle, when deferred, behaves like check.Handle, but first runs the
+// registered cleanups in reverse order. Undos registered with OnFailure only
+// run when recovering Error{err}. Errors returned by cleanups are joined with
+// err, as errors.Join does, before transforms see it. If the function
+// succeeded, errors returned by cleanups are handled as a failure. Any other
+// panic runs only the cleanups registered with Always before it continues.
+func (s *Scope) Handle(e *error, transforms ...func(e error) error) {
+	r := recover()
+	failure, failing := r.(Error)
+	errs := s.run(failing)
+	if len(errs) > 0 {
+		switch {
+		case failing:
+			r = Error{err: join(append([]error{failure.err}, errs...)...), pc: failure.pc}
+		case r == nil:
+			if e != nil && *e != nil {
+				errs = append([]error{*e}, errs...)
+			}
+			r = Error{err: join(errs...)}
+		}
+	}
+	handle(r, math.MinInt, e, transforms...)
+}
+
+// run calls the registered cleanups in reverse order, skipping undos unless
+// failing, and returns their errors.
+func (s *Scope) run(failing bool) []error {
+	var errs []error
+	for i := len(s.cleanups) - 1; i >= 0; i-- {
+		if c := s.cleanups[i]; c.always || failing {
+			if err := c.f(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	s.cleanups = nil
+	return errs
+}
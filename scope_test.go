@@ -0,0 +1,91 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goeezi/check"
+)
+
+// steps runs a Scope that registers an undo and an unconditional cleanup for
+// each of n steps, failing with fail after the last step.
+func steps(log *[]string, n int, fail, cleanupErr error) (e error) {
+	var s check.Scope
+	defer s.Handle(&e, check.Prefix("setup"))
+	for i := 0; i < n; i++ {
+		name := string(rune('a' + i))
+		s.OnFailure(func() error {
+			*log = append(*log, "undo "+name)
+			return nil
+		})
+		s.Always(func() error {
+			*log = append(*log, "close "+name)
+			return cleanupErr
+		})
+	}
+	if fail != nil {
+		check.Fail(fail)
+	}
+	return nil
+}
+
+func TestScope(t *testing.T) {
+	t.Parallel()
+
+	var log []string
+	assert.NoError(t, steps(&log, 2, nil, nil))
+	assert.Equal(t, []string{"close b", "close a"}, log)
+
+	log = nil
+	assert.EqualError(t, steps(&log, 2, errOops, nil), "setup: oops")
+	assert.Equal(t, []string{"close b", "undo b", "close a", "undo a"}, log)
+}
+
+func TestScopeCleanupErrors(t *testing.T) {
+	t.Parallel()
+
+	var log []string
+	err := steps(&log, 1, nil, errOther)
+	assert.EqualError(t, err, "setup: other")
+
+	err = steps(&log, 2, errOops, errOther)
+	assert.EqualError(t, err, "setup: oops\nother\nother")
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+}
+
+func TestScopeReturnedError(t *testing.T) {
+	t.Parallel()
+
+	err := func() (e error) {
+		var s check.Scope
+		defer s.Handle(&e)
+		s.OnFailure(func() error { panic("unexpected undo") })
+		s.Always(func() error { return errOther })
+		return errOops
+	}()
+	assert.ErrorIs(t, err, errOops)
+	assert.ErrorIs(t, err, errOther)
+}
+
+func TestScopePanic(t *testing.T) {
+	t.Parallel()
+
+	var log []string
+	assert.PanicsWithValue(t, 42, func() {
+		var e error
+		var s check.Scope
+		defer s.Handle(&e)
+		s.OnFailure(func() error {
+			log = append(log, "undo")
+			return nil
+		})
+		s.Always(func() error {
+			log = append(log, "close")
+			return nil
+		})
+		panic(42)
+	})
+	assert.Equal(t, []string{"close"}, log)
+}
@@ -0,0 +1,126 @@
+package check
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+)
+
+// StackError is an error returned via Wrap. It records the stack at the point
+// where the failure was raised.
+type StackError struct {
+	err    error
+	frames []runtime.Frame
+}
+
+// Error returns the wrapped error's message, thus implementing the error
+// interface.
+func (e *StackError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error as required by the errors packages.
+func (e *StackError) Unwrap() error {
+	return e.err
+}
+
+// Frames returns the stack, innermost call first, starting at the call to the
+// Must… or Fail… function that raised the failure.
+func (e *StackError) Frames() []runtime.Frame {
+	return e.frames
+}
+
+// Format implements fmt.Formatter. The %+v verb prints the message followed by
+// the stack in the format used by github.com/pkg/errors.
+func (e *StackError) Format(s fmt.State, verb rune) {
+	formatStack(s, verb, e.Error(), fmt.Sprintf("%+v", e.err), e.frames)
+}
+
+// formatStack formats an error with message msg and the given stack. The %+v
+// verb prints detail, the detailed message, followed by the stack.
+func formatStack(s fmt.State, verb rune, msg, detail string, frames []runtime.Frame) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, detail)
+			for _, f := range frames {
+				fmt.Fprintf(s, "\n%s\n\t%s:%d", f.Function, f.File, f.Line)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, msg)
+	case 'q':
+		fmt.Fprintf(s, "%q", msg)
+	}
+}
+
+// stack returns the stack of the panic being recovered by the calling
+// deferred function, starting skip frames above the Must… or Fail… call or
+// panic that raised it.
+func stack(skip int) []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	for {
+		if n := runtime.Callers(2, pcs); n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+
+	var frames []runtime.Frame
+	walk(pcs, func(f runtime.Frame) bool {
+		if skip > 0 {
+			skip--
+		} else {
+			frames = append(frames, f)
+		}
+		return true
+	})
+	return frames
+}
+
+// raiser returns the frame of the Must… or Fail… call or panic that raised
+// the panic being recovered by the calling deferred function. It reports false
+// if there is no such panic.
+func raiser() (frame runtime.Frame, ok bool) {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	walk(pcs[:n], func(f runtime.Frame) bool {
+		frame, ok = f, true
+		return false
+	})
+	return frame, ok
+}
+
+// walk calls yield for each frame of pcs above the Must… or Fail… call or
+// panic that raised the panic being recovered, until yield returns false.
+func walk(pcs []uintptr, yield func(f runtime.Frame) bool) {
+	panicking, raising := false, false
+	it := runtime.CallersFrames(pcs)
+	for more := true; more; {
+		var f runtime.Frame
+		f, more = it.Next()
+		switch {
+		case !panicking:
+			// Drop the frames of the deferred handler and the runtime.
+			panicking = f.Function == "runtime.gopanic"
+			raising = panicking
+		case raising && (isCheckFrame(f) || strings.HasPrefix(f.Function, "runtime.")):
+			// Drop the Must… or Fail… call and its helpers, or the runtime
+			// functions that raise runtime errors.
+		default:
+			raising = false
+			if !yield(f) {
+				return
+			}
+		}
+	}
+}
+
+// isCheckFrame reports whether f belongs to this package.
+func isCheckFrame(f runtime.Frame) bool {
+	return strings.HasPrefix(f.Function, "github.com/goeezi/check.")
+}
@@ -0,0 +1,83 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Prefix returns a transform that prefixes errors with msg, as in
+// fmt.Errorf("%s: %w", msg, e).
+//
+//	defer check.Handle(&e, check.Prefix("loading config"))
+func Prefix(msg string) func(e error) error {
+	return func(e error) error {
+		return fmt.Errorf("%s: %w", msg, e)
+	}
+}
+
+// Wrapf returns a transform that wraps errors as in
+// fmt.Errorf(format+": %w", args..., e).
+//
+//	defer check.Handle(&e, check.Wrapf("loading config %s", name))
+func Wrapf(format string, args ...any) func(e error) error {
+	return func(e error) error {
+		return annotate(e, format, args)
+	}
+}
+
+// Ignore returns a transform that swallows errors matching any of targets, as
+// reported by errors.Is.
+//
+//	defer check.Handle(&e, check.Ignore(fs.ErrNotExist))
+func Ignore(targets ...error) func(e error) error {
+	return func(e error) error {
+		for _, target := range targets {
+			if errors.Is(e, target) {
+				return nil
+			}
+		}
+		return e
+	}
+}
+
+// Replace returns a transform that replaces errors matching target, as
+// reported by errors.Is, with with.
+//
+//	defer check.Handle(&e, check.Replace(sql.ErrNoRows, ErrUserNotFound))
+func Replace(target, with error) func(e error) error {
+	return func(e error) error {
+		if errors.Is(e, target) {
+			return with
+		}
+		return e
+	}
+}
+
+// MapAs returns a transform that replaces errors with f(t) if errors.As finds
+// a t of type T in their chain.
+//
+//	defer check.Handle(&e, check.MapAs(func(e *fs.PathError) error {
+//		return fmt.Errorf("%s: %w", filepath.Base(e.Path), e.Err)
+//	}))
+func MapAs[T error](f func(t T) error) func(e error) error {
+	return func(e error) error {
+		var t T
+		if errors.As(e, &t) {
+			return f(t)
+		}
+		return e
+	}
+}
+
+// OnError returns a transform that calls f with each error and returns it
+// unchanged.
+//
+//	defer check.Handle(&e, check.OnError(func(e error) {
+//		metrics.Failures.Inc()
+//	}))
+func OnError(f func(e error)) func(e error) error {
+	return func(e error) error {
+		f(e)
+		return e
+	}
+}
@@ -0,0 +1,88 @@
+package check_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goeezi/check"
+)
+
+func TestPrefix(t *testing.T) {
+	t.Parallel()
+
+	err := check.Catch(func() { check.Fail(errOops) }, check.Prefix("doing stuff"))
+	assert.EqualError(t, err, "doing stuff: oops")
+	assert.ErrorIs(t, err, errOops)
+}
+
+func TestWrapf(t *testing.T) {
+	t.Parallel()
+
+	err := check.Catch(func() { check.Fail(errOops) }, check.Wrapf("doing %s %d", "stuff", 42))
+	assert.EqualError(t, err, "doing stuff 42: oops")
+	assert.ErrorIs(t, err, errOops)
+}
+
+func TestIgnore(t *testing.T) {
+	t.Parallel()
+
+	ignore := check.Ignore(errOops, fs.ErrNotExist)
+	assert.NoError(t, check.Catch(func() { check.Fail(errOops) }, ignore))
+	assert.NoError(t, check.Catch(func() {
+		check.Must1(os.Open("/no/such/file"))
+	}, ignore))
+	assert.ErrorIs(t, check.Catch(func() { check.Fail(errOther) }, ignore), errOther)
+	assert.ErrorIs(t, check.Catch(func() { check.Fail(errOops) }, check.Ignore()), errOops)
+}
+
+func TestReplace(t *testing.T) {
+	t.Parallel()
+
+	replace := check.Replace(errOops, errOther)
+	assert.Equal(t, errOther, check.Catch(func() { check.Fail(errOops) }, replace))
+	assert.Equal(t, errOther, check.Catch(func() { check.Fail(errOther) }, replace))
+	assert.Equal(t, fs.ErrClosed, check.Catch(func() { check.Fail(fs.ErrClosed) }, replace))
+}
+
+func TestMapAs(t *testing.T) {
+	t.Parallel()
+
+	mapPath := check.MapAs(func(e *fs.PathError) error {
+		return e.Err
+	})
+	err := check.Catch(func() {
+		check.Must1(os.Open("/no/such/file"))
+	}, mapPath)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	var perr *fs.PathError
+	assert.False(t, errors.As(err, &perr))
+	assert.Equal(t, errOops, check.Catch(func() { check.Fail(errOops) }, mapPath))
+}
+
+func TestOnError(t *testing.T) {
+	t.Parallel()
+
+	var seen []error
+	record := check.OnError(func(e error) { seen = append(seen, e) })
+	assert.NoError(t, check.Catch(func() {}, record))
+	assert.Equal(t, errOops, check.Catch(func() { check.Fail(errOops) }, record))
+	assert.Equal(t, []error{errOops}, seen)
+}
+
+func TestTransformComposition(t *testing.T) {
+	t.Parallel()
+
+	var seen []error
+	transforms := []func(e error) error{
+		check.OnError(func(e error) { seen = append(seen, e) }),
+		check.Ignore(errOther),
+		check.Prefix("outer"),
+	}
+	assert.NoError(t, check.Catch(func() { check.Fail(errOther) }, transforms...))
+	assert.EqualError(t, check.Catch(func() { check.Fail(errOops) }, transforms...), "outer: oops")
+	assert.Equal(t, []error{errOther, errOops}, seen)
+}